@@ -0,0 +1,147 @@
+package jubjub
+
+import (
+	"crypto/rand"
+)
+
+// batchWeightSize is the size of the random weights in bytes. A batch
+// containing an invalid equation passes with probability at most 2^-128
+const batchWeightSize = 16
+
+// BatchVerifier checks many Schnorr-style equations [s]B = R + [c]A
+// with a single multi-scalar multiplication. The equations are checked
+// with the cofactor cleared, [8]([s]B - R - [c]A) = O, as this is the
+// only form for which batch and individual verification always agree
+type BatchVerifier struct {
+	entries []batchEntry
+	// bases holds the distinct base points, indexed by their value
+	bases     []Point
+	baseIndex map[Point]int
+}
+
+type batchEntry struct {
+	// b is the index of the base point in bases
+	b    int
+	r, a Point
+	s, c Scalar
+	// invalid marks an entry that failed to parse
+	invalid bool
+}
+
+// NewBatchVerifier returns an empty BatchVerifier
+func NewBatchVerifier() *BatchVerifier {
+	return &BatchVerifier{baseIndex: make(map[Point]int)}
+}
+
+// Add queues the equation [s]B = R + [c]A. Equations are indexed in the
+// order they are added. B, R and A are copied. Equations whose base
+// points have the same value share one term
+func (bv *BatchVerifier) Add(B, R, A *Point, s, c Scalar) {
+	b, ok := bv.baseIndex[*B]
+	if !ok {
+		b = len(bv.bases)
+		bv.baseIndex[*B] = b
+		bv.bases = append(bv.bases, *B)
+	}
+	bv.entries = append(bv.entries, batchEntry{b: b, r: *R, a: *A, s: s, c: c})
+}
+
+// AddInvalid queues an equation that is known not to hold, such as one
+// whose encoding failed to parse, so that it keeps its index
+func (bv *BatchVerifier) AddInvalid() {
+	bv.entries = append(bv.entries, batchEntry{invalid: true})
+}
+
+// Len returns the number of queued equations
+func (bv *BatchVerifier) Len() int {
+	return len(bv.entries)
+}
+
+// Verify returns true if all queued equations hold, and -1. Otherwise it
+// returns false and the index of the first one that does not hold,
+// verifying the equations one by one if needed. opts configure the
+// multi-scalar multiplication. All inputs are treated as public
+func (bv *BatchVerifier) Verify(opts ...MSMOption) (bool, int) {
+	if bv.check(opts) {
+		// Every valid equation holds, report the first invalid one
+		for i := range bv.entries {
+			if bv.entries[i].invalid {
+				return false, i
+			}
+		}
+		return true, -1
+	}
+
+	for i := range bv.entries {
+		if e := &bv.entries[i]; e.invalid || !e.verify(&bv.bases[e.b]) {
+			return false, i
+		}
+	}
+
+	// Unreachable: if every equation holds, so does any linear combination
+	return false, -1
+}
+
+// check computes
+// sum(z_i.R_i) + sum(z_i.c_i.A_i) - sum_B(sum(z_i.s_i)).B
+// for random 128 bit weights z_i, and checks that it vanishes after
+// clearing the cofactor. Entries sharing a base point share one term,
+// and invalid entries are skipped
+func (bv *BatchVerifier) check(opts []MSMOption) bool {
+	n := len(bv.entries)
+	if n == 0 {
+		return true
+	}
+
+	scalars := make([]Scalar, 0, 2*n+len(bv.bases))
+	points := make([]Point, 0, 2*n+len(bv.bases))
+	baseScalars := make([]Scalar, len(bv.bases))
+
+	var z, zc, zs Scalar
+	for i := range bv.entries {
+		e := &bv.entries[i]
+		if e.invalid {
+			continue
+		}
+		z = randomWeight()
+
+		zc.Mul(z, e.c)
+		zs.Mul(z, e.s)
+		scalars = append(scalars, z, zc)
+		points = append(points, e.r, e.a)
+		baseScalars[e.b].Add(baseScalars[e.b], zs)
+	}
+
+	for j := range baseScalars {
+		baseScalars[j].Neg(baseScalars[j])
+	}
+	scalars = append(scalars, baseScalars...)
+	points = append(points, bv.bases...)
+
+	sum := MultiScalarMultVarTime(scalars, points, opts...)
+	sum.ClearCofactor(*sum)
+	return sum.IsIdentity()
+}
+
+// verify checks [8]([s]B - R - [c]A) = O for a single entry
+func (e *batchEntry) verify(B *Point) bool {
+	var negC Scalar
+	negC.Neg(e.c)
+
+	sum := MultiScalarMultVarTime([]Scalar{e.s, negC}, []Point{*B, e.a})
+	sum.Sub(*sum, e.r)
+	sum.ClearCofactor(*sum)
+	return sum.IsIdentity()
+}
+
+// randomWeight returns a uniformly random scalar of batchWeightSize bytes
+func randomWeight() Scalar {
+	var buf [64]byte
+	if _, err := rand.Read(buf[:batchWeightSize]); err != nil {
+		panic("jubjub: failed to read randomness: " + err.Error())
+	}
+
+	var z Scalar
+	z.FromBytes(buf)
+	return z
+}
@@ -0,0 +1,152 @@
+package jubjub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// randomEquation returns B, R, A, s, c with [s]B = R + [c]A
+func randomEquation(B *Point) (R, A Point, s, c Scalar) {
+	var a, r Scalar
+	a.Rand()
+	r.Rand()
+	c.Rand()
+
+	A.ScalarMult(a, B)
+	R.ScalarMult(r, B)
+	s.MulAdd(c, a, r)
+	return
+}
+
+func TestBatchVerifier(t *testing.T) {
+	var B, B2 Point
+	B.SetBase()
+	B2.ScalarMultBase(randomScalar())
+
+	bv := NewBatchVerifier()
+	ok, idx := bv.Verify()
+	assert.True(t, ok)
+	assert.Equal(t, -1, idx)
+
+	for i := 0; i < 20; i++ {
+		base := &B
+		if i%3 == 0 {
+			base = &B2
+		}
+		R, A, s, c := randomEquation(base)
+		bv.Add(base, &R, &A, s, c)
+	}
+	assert.Equal(t, 20, bv.Len())
+
+	ok, idx = bv.Verify()
+	assert.True(t, ok)
+	assert.Equal(t, -1, idx)
+
+	ok, _ = bv.Verify(Parallel(4))
+	assert.True(t, ok)
+
+	// Break equations 13 and 17, the first one is reported
+	bv.entries[17].s.Add(bv.entries[17].s, bv.entries[17].s)
+	bv.entries[13].c.Add(bv.entries[13].c, bv.entries[13].c)
+
+	ok, idx = bv.Verify()
+	assert.False(t, ok)
+	assert.Equal(t, 13, idx)
+}
+
+func TestBatchVerifierInvalid(t *testing.T) {
+	var B Point
+	B.SetBase()
+
+	bv := NewBatchVerifier()
+	bv.AddInvalid()
+	ok, idx := bv.Verify()
+	assert.False(t, ok)
+	assert.Equal(t, 0, idx)
+
+	bv = NewBatchVerifier()
+	for i := 0; i < 8; i++ {
+		if i == 3 || i == 6 {
+			bv.AddInvalid()
+			continue
+		}
+		R, A, s, c := randomEquation(&B)
+		bv.Add(&B, &R, &A, s, c)
+	}
+	assert.Equal(t, 8, bv.Len())
+
+	// The valid equations hold, the first invalid one is reported
+	ok, idx = bv.Verify()
+	assert.False(t, ok)
+	assert.Equal(t, 3, idx)
+
+	// An earlier equation that does not hold is reported instead
+	bv.entries[1].s.Add(bv.entries[1].s, bv.entries[1].s)
+	ok, idx = bv.Verify()
+	assert.False(t, ok)
+	assert.Equal(t, 1, idx)
+}
+
+func TestBatchVerifierBases(t *testing.T) {
+	var B, B2 Point
+	B.SetBase()
+	B2.ScalarMultBase(randomScalar())
+
+	bv := NewBatchVerifier()
+	for i := 0; i < 6; i++ {
+		// Equal bases behind different pointers share a term
+		base := new(Point).Set(B)
+		if i%2 == 0 {
+			base.Set(B2)
+		}
+		R, A, s, c := randomEquation(base)
+		bv.Add(base, &R, &A, s, c)
+
+		// B is copied, changing it afterwards has no effect
+		base.Identity()
+	}
+	assert.Equal(t, 2, len(bv.bases))
+
+	ok, idx := bv.Verify()
+	assert.True(t, ok)
+	assert.Equal(t, -1, idx)
+}
+
+func TestBatchVerifierCofactored(t *testing.T) {
+	var B Point
+	B.SetBase()
+
+	R, A, s, c := randomEquation(&B)
+
+	// An equation that only holds up to a small order component
+	// passes, as batch verification is cofactored
+	R.Add(R, torsionPoint())
+
+	bv := NewBatchVerifier()
+	bv.Add(&B, &R, &A, s, c)
+	ok, _ := bv.Verify()
+	assert.True(t, ok)
+}
+
+func randomScalar() Scalar {
+	var s Scalar
+	s.Rand()
+	return s
+}
+
+func BenchmarkBatchVerifier(b *testing.B) {
+	var B Point
+	B.SetBase()
+
+	bv := NewBatchVerifier()
+	for i := 0; i < 64; i++ {
+		R, A, s, c := randomEquation(&B)
+		bv.Add(&B, &R, &A, s, c)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bv.Verify()
+	}
+}
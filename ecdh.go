@@ -0,0 +1,148 @@
+package jubjub
+
+import (
+	"crypto"
+	"crypto/subtle"
+	"errors"
+	"io"
+)
+
+// ECDH returns the Diffie-Hellman shared secret [8 * priv]pub, encoded as
+// a point. It returns an error if pub has small order or if the secret is
+// the identity, which happens when priv is zero.
+// Runs in constant time with respect to priv
+func ECDH(priv Scalar, pub *Point) ([]byte, error) {
+	if pub.IsSmallOrder() {
+		return nil, errors.New("jubjub: peer point has small order")
+	}
+
+	var shared Point
+	shared.ClearCofactor(*pub)
+	shared.ScalarMult(priv, &shared)
+
+	if shared.IsIdentity() {
+		return nil, errors.New("jubjub: shared secret is the identity")
+	}
+	return shared.Bytes(), nil
+}
+
+// PrivateKey is a Diffie-Hellman private key, in the style of crypto/ecdh
+type PrivateKey struct {
+	s   Scalar
+	pub *PublicKey
+}
+
+// PublicKey is a Diffie-Hellman public key, in the style of crypto/ecdh
+type PublicKey struct {
+	p     Point
+	bytes [32]byte
+}
+
+// GenerateKey returns a new private key using randomness from rand
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	var buf [64]byte
+	for {
+		if _, err := io.ReadFull(rand, buf[:]); err != nil {
+			return nil, err
+		}
+
+		var s Scalar
+		s.FromBytes(buf)
+		if !s.IsZero() {
+			return newPrivateKey(s), nil
+		}
+	}
+}
+
+// NewPrivateKey decodes a private key from the canonical little endian
+// encoding of a non-zero scalar
+func NewPrivateKey(key []byte) (*PrivateKey, error) {
+	var s Scalar
+	if _, err := s.SetCanonicalBytesSlice(key); err != nil {
+		return nil, err
+	}
+	if s.IsZero() {
+		return nil, errors.New("jubjub: private key is zero")
+	}
+
+	return newPrivateKey(s), nil
+}
+
+func newPrivateKey(s Scalar) *PrivateKey {
+	var p Point
+	p.ScalarMultBase(s)
+
+	pub := &PublicKey{p: p}
+	copy(pub.bytes[:], p.Bytes())
+	return &PrivateKey{s: s, pub: pub}
+}
+
+// NewPublicKey decodes a public key. It returns an error if key is not
+// the canonical encoding of a point, or if the point has small order
+func NewPublicKey(key []byte) (*PublicKey, error) {
+	var p Point
+	if _, err := p.SetBytes(key); err != nil {
+		return nil, err
+	}
+	if p.IsSmallOrder() {
+		return nil, errors.New("jubjub: public key has small order")
+	}
+
+	pub := &PublicKey{p: p}
+	copy(pub.bytes[:], key)
+	return pub, nil
+}
+
+// ECDH returns the shared secret of k and remote, see ECDH
+func (k *PrivateKey) ECDH(remote *PublicKey) ([]byte, error) {
+	return ECDH(k.s, &remote.p)
+}
+
+// Bytes returns the encoding of the private key
+func (k *PrivateKey) Bytes() []byte {
+	var buf [32]byte
+	k.s.BytesInto(&buf)
+	return buf[:]
+}
+
+// PublicKey returns the public key of k
+func (k *PrivateKey) PublicKey() *PublicKey {
+	return k.pub
+}
+
+// Public returns the public key of k, implementing the implicit
+// interface of the private keys of the standard library
+func (k *PrivateKey) Public() crypto.PublicKey {
+	return k.pub
+}
+
+// Equal reports whether k and x are the same private key.
+// Runs in constant time
+func (k *PrivateKey) Equal(x crypto.PrivateKey) bool {
+	xx, ok := x.(*PrivateKey)
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare(k.Bytes(), xx.Bytes()) == 1
+}
+
+// Bytes returns the encoding of the public key
+func (k *PublicKey) Bytes() []byte {
+	b := k.bytes
+	return b[:]
+}
+
+// Point returns the point of the public key
+func (k *PublicKey) Point() *Point {
+	p := k.p
+	return &p
+}
+
+// Equal reports whether k and x are the same public key
+func (k *PublicKey) Equal(x crypto.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	if !ok {
+		return false
+	}
+	return k.bytes == xx.bytes
+}
@@ -0,0 +1,97 @@
+package jubjub
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Vectors computed with an independent implementation
+func TestECDHVectors(t *testing.T) {
+	alicePriv, _ := hex.DecodeString("9fce7e8f0c18c9683f5cc0c5b49bc1b9a562057fab55ea125f1a6fed8e2f1004")
+	bobPriv, _ := hex.DecodeString("7ae4c9b9f11f7956ad4fd22398ae319edce41b8f9203fe493794917f31d32a02")
+
+	alice, err := NewPrivateKey(alicePriv)
+	assert.Nil(t, err)
+	bob, err := NewPrivateKey(bobPriv)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "1be9c8458c24e3b5ee43c3f48b3e8b0d063c08729c79a5f7ddcbb6a992ff6509", hex.EncodeToString(alice.PublicKey().Bytes()))
+	assert.Equal(t, "d226c9db510ee3de8842d0440eb63dd9ff4d7bf4c142cfdbef3f2ed168cd7b2f", hex.EncodeToString(bob.PublicKey().Bytes()))
+
+	s1, err := alice.ECDH(bob.PublicKey())
+	assert.Nil(t, err)
+	s2, err := bob.ECDH(alice.PublicKey())
+	assert.Nil(t, err)
+	assert.Equal(t, "90956321ceadb5f4876a8d0ff1711866870954eb6356cffa8c1df8ebf5158b1f", hex.EncodeToString(s1))
+	assert.Equal(t, s1, s2)
+}
+
+func TestECDHAgreement(t *testing.T) {
+	alice, err := GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	bob, err := GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+
+	// Round trip the public key through its encoding
+	bobPub, err := NewPublicKey(bob.PublicKey().Bytes())
+	assert.Nil(t, err)
+	assert.True(t, bobPub.Equal(bob.PublicKey()))
+	assert.True(t, bobPub.Equal(bob.Public()))
+
+	s1, err := alice.ECDH(bobPub)
+	assert.Nil(t, err)
+	s2, err := ECDH(bob.s, alice.PublicKey().Point())
+	assert.Nil(t, err)
+	assert.Equal(t, s1, s2)
+
+	// Round trip the private key through its encoding
+	alice2, err := NewPrivateKey(alice.Bytes())
+	assert.Nil(t, err)
+	assert.True(t, alice2.Equal(alice))
+	assert.False(t, alice2.Equal(bob))
+
+	// Adding a small order component to the peer point does not
+	// change the secret, as the cofactor is cleared
+	var p Point
+	p.Add(*bobPub.Point(), torsionPoint())
+	s3, err := ECDH(alice.s, &p)
+	assert.Nil(t, err)
+	assert.Equal(t, s1, s3)
+}
+
+func TestECDHInvalid(t *testing.T) {
+	alice, err := GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+
+	// Small order peers are rejected
+	small := torsionPoint()
+	_, err = ECDH(alice.s, &small)
+	assert.NotNil(t, err)
+	_, err = NewPublicKey(small.Bytes())
+	assert.NotNil(t, err)
+
+	var id Point
+	id.Identity()
+	_, err = ECDH(alice.s, &id)
+	assert.NotNil(t, err)
+
+	// A zero private key gives the identity
+	var zero Scalar
+	_, err = ECDH(zero, alice.PublicKey().Point())
+	assert.NotNil(t, err)
+	_, err = NewPrivateKey(make([]byte, 32))
+	assert.NotNil(t, err)
+
+	// r itself is not a canonical private key
+	r, _ := hex.DecodeString("b72cf7d65e0e97d08210c8cc932068a6003b3401013b6706a9af3365eab47d0e")
+	_, err = NewPrivateKey(r)
+	assert.NotNil(t, err)
+	_, err = NewPrivateKey(r[:31])
+	assert.NotNil(t, err)
+
+	_, err = NewPublicKey(make([]byte, 31))
+	assert.NotNil(t, err)
+}
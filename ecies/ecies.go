@@ -0,0 +1,130 @@
+// Package ecies implements hybrid public key encryption to Jubjub keys.
+// Each message is encrypted under a fresh ephemeral key: the shared
+// secret of jubjub.ECDH is expanded with HKDF-SHA256 into a
+// ChaCha20-Poly1305 key that is used exactly once.
+//
+// A sealed message is laid out as
+//
+//	version (1 byte) || ephemeral public key (32 bytes) || AEAD ciphertext
+package ecies
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+
+	jubjub "github.com/decentralisedkev/go-jubjub"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// Version1 is the version byte of the current wire format
+	Version1 = 0x01
+
+	// Overhead is the size difference between a sealed message and its
+	// plaintext
+	Overhead = headerSize + tagSize
+
+	// ephemeralSize is the size of an encoded public key
+	ephemeralSize = 32
+	headerSize    = 1 + ephemeralSize
+	// tagSize is the size of the Poly1305 authentication tag
+	tagSize = 16
+)
+
+// info is the HKDF info prefix of Version1. It is followed by the
+// ephemeral and recipient public keys
+var info = []byte("JubjubECIES-v1")
+
+// errOpen is returned for every authentication failure so that callers
+// cannot learn which check failed
+var errOpen = errors.New("ecies: message authentication failed")
+
+// Seal encrypts and authenticates plaintext to pub, and authenticates
+// aad, which is not included in the result. The same aad must be given
+// to Open
+func Seal(pub *jubjub.PublicKey, plaintext, aad []byte) ([]byte, error) {
+	return seal(rand.Reader, pub, plaintext, aad)
+}
+
+func seal(rand io.Reader, pub *jubjub.PublicKey, plaintext, aad []byte) ([]byte, error) {
+	eph, err := jubjub.GenerateKey(rand)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := eph.ECDH(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	ephPub := eph.PublicKey().Bytes()
+	aead, err := newAEAD(shared, ephPub, pub.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, headerSize, headerSize+len(plaintext)+aead.Overhead())
+	out[0] = Version1
+	copy(out[1:], ephPub)
+
+	// The key is never reused, so a fixed nonce is safe
+	var nonce [chacha20poly1305.NonceSize]byte
+	return aead.Seal(out, nonce[:], plaintext, aad), nil
+}
+
+// Open decrypts and authenticates a message produced by Seal for the
+// public key of priv, with the same aad
+func Open(priv *jubjub.PrivateKey, ciphertext, aad []byte) ([]byte, error) {
+	if len(ciphertext) < Overhead {
+		return nil, errors.New("ecies: ciphertext too short")
+	}
+	if ciphertext[0] != Version1 {
+		return nil, errors.New("ecies: unsupported version")
+	}
+
+	ephPub := ciphertext[1:headerSize]
+	eph, err := jubjub.NewPublicKey(ephPub)
+	if err != nil {
+		return nil, errOpen
+	}
+	shared, err := priv.ECDH(eph)
+	if err != nil {
+		return nil, errOpen
+	}
+
+	aead, err := newAEAD(shared, ephPub, priv.PublicKey().Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce [chacha20poly1305.NonceSize]byte
+	plaintext, err := aead.Open(nil, nonce[:], ciphertext[headerSize:], aad)
+	if err != nil {
+		return nil, errOpen
+	}
+	return plaintext, nil
+}
+
+// deriveKey returns
+// HKDF-SHA256(ikm = shared, salt = nil, info = info || ephPub || pub)
+// Binding both public keys makes the key depend on the exact encoding of
+// the header and on the intended recipient
+func deriveKey(shared, ephPub, pub []byte) []byte {
+	i := make([]byte, 0, len(info)+len(ephPub)+len(pub))
+	i = append(i, info...)
+	i = append(i, ephPub...)
+	i = append(i, pub...)
+
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, shared, nil, i), key); err != nil {
+		panic("ecies: " + err.Error())
+	}
+	return key
+}
+
+func newAEAD(shared, ephPub, pub []byte) (cipher.AEAD, error) {
+	return chacha20poly1305.New(deriveKey(shared, ephPub, pub))
+}
@@ -0,0 +1,130 @@
+package ecies
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	jubjub "github.com/decentralisedkev/go-jubjub"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+func newKey(t *testing.T) *jubjub.PrivateKey {
+	k, err := jubjub.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	return k
+}
+
+func TestSealOpen(t *testing.T) {
+	k := newKey(t)
+
+	for _, size := range []int{0, 1, 64, 1000} {
+		msg := make([]byte, size)
+		rand.Read(msg)
+		aad := []byte("note")
+
+		ct, err := Seal(k.PublicKey(), msg, aad)
+		assert.Nil(t, err)
+		assert.Len(t, ct, size+Overhead)
+		assert.Equal(t, byte(Version1), ct[0])
+
+		pt, err := Open(k, ct, aad)
+		assert.Nil(t, err)
+		assert.True(t, bytes.Equal(msg, pt))
+	}
+
+	// Sealing is randomised
+	ct1, _ := Seal(k.PublicKey(), []byte("abc"), nil)
+	ct2, _ := Seal(k.PublicKey(), []byte("abc"), nil)
+	assert.NotEqual(t, ct1, ct2)
+}
+
+// The key derivation is checked against an independent HKDF-SHA256,
+// with the keys of the ECDH vectors in the root package
+func TestVector(t *testing.T) {
+	ephPriv, _ := hex.DecodeString("9fce7e8f0c18c9683f5cc0c5b49bc1b9a562057fab55ea125f1a6fed8e2f1004")
+	recipientPriv, _ := hex.DecodeString("7ae4c9b9f11f7956ad4fd22398ae319edce41b8f9203fe493794917f31d32a02")
+	ephPub, _ := hex.DecodeString("1be9c8458c24e3b5ee43c3f48b3e8b0d063c08729c79a5f7ddcbb6a992ff6509")
+	key, _ := hex.DecodeString("84127cfca2f97b0783b8707b3dfbd8200987bdb40a2b659171a37989022de4b3")
+
+	k, err := jubjub.NewPrivateKey(recipientPriv)
+	assert.Nil(t, err)
+
+	// The ephemeral key is drawn from 64 bytes reduced mod r
+	r := bytes.NewReader(append(ephPriv, make([]byte, 32)...))
+	msg, aad := []byte("hello jubjub"), []byte("aad")
+	ct, err := seal(r, k.PublicKey(), msg, aad)
+	assert.Nil(t, err)
+	assert.Equal(t, append([]byte{Version1}, ephPub...), ct[:headerSize])
+
+	aead, err := chacha20poly1305.New(key)
+	assert.Nil(t, err)
+	pt, err := aead.Open(nil, make([]byte, chacha20poly1305.NonceSize), ct[headerSize:], aad)
+	assert.Nil(t, err)
+	assert.Equal(t, msg, pt)
+}
+
+func TestTamper(t *testing.T) {
+	k := newKey(t)
+	msg, aad := []byte("attack at dawn"), []byte("header")
+
+	ct, err := Seal(k.PublicKey(), msg, aad)
+	assert.Nil(t, err)
+
+	// Every bit of the message is authenticated
+	for i := range ct {
+		for bit := uint(0); bit < 8; bit++ {
+			bad := append([]byte{}, ct...)
+			bad[i] ^= 1 << bit
+			_, err := Open(k, bad, aad)
+			assert.NotNil(t, err, "byte %d bit %d", i, bit)
+		}
+	}
+
+	// Wrong aad
+	_, err = Open(k, ct, []byte("Header"))
+	assert.NotNil(t, err)
+	_, err = Open(k, ct, nil)
+	assert.NotNil(t, err)
+
+	// Wrong recipient
+	_, err = Open(newKey(t), ct, aad)
+	assert.NotNil(t, err)
+
+	// Truncated or extended
+	for _, n := range []int{0, 1, headerSize, Overhead - 1, len(ct) - 1} {
+		_, err = Open(k, ct[:n], aad)
+		assert.NotNil(t, err, "length %d", n)
+	}
+	_, err = Open(k, append(ct, 0), aad)
+	assert.NotNil(t, err)
+}
+
+func TestVersion(t *testing.T) {
+	k := newKey(t)
+	ct, err := Seal(k.PublicKey(), []byte("msg"), nil)
+	assert.Nil(t, err)
+
+	for _, v := range []byte{0x00, 0x02, 0xff} {
+		bad := append([]byte{}, ct...)
+		bad[0] = v
+		_, err := Open(k, bad, nil)
+		assert.EqualError(t, err, "ecies: unsupported version")
+	}
+}
+
+func TestSmallOrderEphemeral(t *testing.T) {
+	k := newKey(t)
+	ct, err := Seal(k.PublicKey(), []byte("msg"), nil)
+	assert.Nil(t, err)
+
+	// Replace the ephemeral key with the identity, which has small order
+	var id jubjub.Point
+	id.Identity()
+	copy(ct[1:headerSize], id.Bytes())
+
+	_, err = Open(k, ct, nil)
+	assert.Equal(t, errOpen, err)
+}
@@ -0,0 +1,42 @@
+package eddsa
+
+import (
+	"strconv"
+
+	jubjub "github.com/decentralisedkev/go-jubjub"
+)
+
+// BatchVerifier verifies many signatures at once. It accepts exactly
+// the signatures accepted by Verify, which uses the cofactored equation
+type BatchVerifier struct {
+	bv *jubjub.BatchVerifier
+}
+
+// NewBatchVerifier returns an empty BatchVerifier
+func NewBatchVerifier() *BatchVerifier {
+	return &BatchVerifier{bv: jubjub.NewBatchVerifier()}
+}
+
+// Add queues the signature sig of message by pub. Signatures are indexed
+// in the order they are added.
+// Panics if len(pub) is not PublicKeySize
+func (v *BatchVerifier) Add(pub PublicKey, message, sig []byte) {
+	if l := len(pub); l != PublicKeySize {
+		panic("eddsa: bad public key length: " + strconv.Itoa(l))
+	}
+
+	A, R, S, k, ok := parse(pub, message, sig)
+	if !ok {
+		v.bv.AddInvalid()
+		return
+	}
+	var B jubjub.Point
+	B.SetBase()
+	v.bv.Add(&B, &R, &A, S, k)
+}
+
+// Verify returns true if all queued signatures are valid, and -1.
+// Otherwise it returns false and the index of the first invalid signature
+func (v *BatchVerifier) Verify(opts ...jubjub.MSMOption) (bool, int) {
+	return v.bv.Verify(opts...)
+}
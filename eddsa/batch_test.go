@@ -0,0 +1,84 @@
+package eddsa
+
+import (
+	"crypto/rand"
+	"strconv"
+	"testing"
+
+	jubjub "github.com/decentralisedkev/go-jubjub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchVerifier(t *testing.T) {
+	const n = 32
+	pubs := make([]PublicKey, n)
+	msgs := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := range pubs {
+		pub, priv, err := GenerateKey(rand.Reader)
+		assert.Nil(t, err)
+		pubs[i] = pub
+		msgs[i] = []byte("message " + strconv.Itoa(i))
+		sigs[i] = Sign(priv, msgs[i])
+	}
+
+	batch := func(mutate func(i int, sig []byte) []byte) *BatchVerifier {
+		v := NewBatchVerifier()
+		for i := range pubs {
+			sig := append([]byte(nil), sigs[i]...)
+			v.Add(pubs[i], msgs[i], mutate(i, sig))
+		}
+		return v
+	}
+
+	ok, idx := batch(func(_ int, sig []byte) []byte { return sig }).Verify()
+	assert.True(t, ok)
+	assert.Equal(t, -1, idx)
+
+	// An invalid signature is found by the fallback
+	ok, idx = batch(func(i int, sig []byte) []byte {
+		if i == 21 {
+			sig[40] ^= 1
+		}
+		return sig
+	}).Verify(jubjub.Parallel(2))
+	assert.False(t, ok)
+	assert.Equal(t, 21, idx)
+
+	// A malformed signature is reported without running the fallback
+	ok, idx = batch(func(i int, sig []byte) []byte {
+		if i == 9 {
+			return sig[:10]
+		}
+		return sig
+	}).Verify()
+	assert.False(t, ok)
+	assert.Equal(t, 9, idx)
+
+	// The first failure is reported, whether malformed or invalid
+	ok, idx = batch(func(i int, sig []byte) []byte {
+		switch i {
+		case 5:
+			sig[0] ^= 1
+		case 12:
+			return nil
+		}
+		return sig
+	}).Verify()
+	assert.False(t, ok)
+	assert.Equal(t, 5, idx)
+}
+
+func BenchmarkBatchVerify64(b *testing.B) {
+	v := NewBatchVerifier()
+	for i := 0; i < 64; i++ {
+		pub, priv, _ := GenerateKey(rand.Reader)
+		msg := []byte("message " + strconv.Itoa(i))
+		v.Add(pub, msg, Sign(priv, msg))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		v.Verify()
+	}
+}
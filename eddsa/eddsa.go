@@ -0,0 +1,239 @@
+// Package eddsa implements Schnorr signatures over the prime order
+// subgroup of Jubjub in the style of Ed25519: keys are derived from a
+// 32 byte seed, nonces are derived deterministically from a secret
+// prefix and the message, and signatures are 64 bytes long
+package eddsa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha512"
+	"errors"
+	"io"
+	"strconv"
+
+	jubjub "github.com/decentralisedkev/go-jubjub"
+)
+
+const (
+	// SeedSize is the size of a private key seed in bytes
+	SeedSize = 32
+	// PublicKeySize is the size of a public key in bytes
+	PublicKeySize = 32
+	// PrivateKeySize is the size of a private key in bytes
+	PrivateKeySize = 64
+	// SignatureSize is the size of a signature in bytes
+	SignatureSize = 64
+)
+
+// The domain separation tags of the scalars derived by the scheme, so
+// that it does not share hash inputs with other protocols over Jubjub
+var (
+	domain       = []byte("JubjubEdDSA")
+	keyDST       = []byte("JubjubEdDSA-key")
+	nonceDST     = []byte("JubjubEdDSA-nonce")
+	challengeDST = []byte("JubjubEdDSA-challenge")
+)
+
+// Mode selects the verification equation
+type Mode int
+
+const (
+	// Cofactored checks [8][S]B = [8]R + [8][k]A. It accepts the same
+	// signatures as batch verification
+	Cofactored Mode = iota
+	// Cofactorless checks [S]B = R + [k]A
+	Cofactorless
+)
+
+// PublicKey is an encoded Jubjub point
+type PublicKey []byte
+
+// Equal reports whether pub and x have the same value
+func (pub PublicKey) Equal(x crypto.PublicKey) bool {
+	xx, ok := x.(PublicKey)
+	if !ok {
+		return false
+	}
+	return bytes.Equal(pub, xx)
+}
+
+// PrivateKey is the seed followed by the public key
+type PrivateKey []byte
+
+// Public returns the PublicKey corresponding to priv
+func (priv PrivateKey) Public() crypto.PublicKey {
+	pub := make([]byte, PublicKeySize)
+	copy(pub, priv[SeedSize:])
+	return PublicKey(pub)
+}
+
+// Seed returns the private key seed corresponding to priv
+func (priv PrivateKey) Seed() []byte {
+	seed := make([]byte, SeedSize)
+	copy(seed, priv[:SeedSize])
+	return seed
+}
+
+// Sign signs message with priv. rand is ignored, as signing is
+// deterministic. opts.HashFunc() must return zero, as the message
+// is signed directly rather than a digest of it.
+// This implements crypto.Signer
+func (priv PrivateKey) Sign(rand io.Reader, message []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if opts.HashFunc() != crypto.Hash(0) {
+		return nil, errors.New("eddsa: cannot sign hashed message")
+	}
+	return Sign(priv, message), nil
+}
+
+// GenerateKey generates a public/private key pair using randomness from rand
+func GenerateKey(rand io.Reader) (PublicKey, PrivateKey, error) {
+	seed := make([]byte, SeedSize)
+	if _, err := io.ReadFull(rand, seed); err != nil {
+		return nil, nil, err
+	}
+
+	priv := NewKeyFromSeed(seed)
+	return priv.Public().(PublicKey), priv, nil
+}
+
+// NewKeyFromSeed calculates a private key from a seed.
+// Panics if len(seed) is not SeedSize
+func NewKeyFromSeed(seed []byte) PrivateKey {
+	if l := len(seed); l != SeedSize {
+		panic("eddsa: bad seed length: " + strconv.Itoa(l))
+	}
+
+	a, _ := expandSeed(seed)
+	var A jubjub.Point
+	A.ScalarMultBase(a)
+
+	priv := make([]byte, PrivateKeySize)
+	copy(priv, seed)
+	copy(priv[SeedSize:], A.Bytes())
+	return priv
+}
+
+// expandSeed derives the secret scalar a and the nonce prefix from seed:
+// a = HashToScalar(seed, keyDST)
+// prefix = SHA-512(domain || 0x01 || seed)[:32]
+func expandSeed(seed []byte) (jubjub.Scalar, []byte) {
+	a := jubjub.HashToScalar(seed, keyDST, 1)[0]
+
+	h := sha512.New()
+	h.Write(domain)
+	h.Write([]byte{1})
+	h.Write(seed)
+	prefix := h.Sum(nil)[:32]
+
+	return a, prefix
+}
+
+// Sign signs message with priv and returns the signature R || S.
+// Panics if len(priv) is not PrivateKeySize
+func Sign(priv PrivateKey, message []byte) []byte {
+	if l := len(priv); l != PrivateKeySize {
+		panic("eddsa: bad private key length: " + strconv.Itoa(l))
+	}
+
+	a, prefix := expandSeed(priv[:SeedSize])
+	A := priv[SeedSize:]
+
+	r := nonce(prefix, message)
+
+	var R jubjub.Point
+	R.ScalarMultBase(r)
+	RBytes := R.Bytes()
+
+	// S = r + k * a
+	k := challenge(RBytes, A, message)
+	var S jubjub.Scalar
+	S.MulAdd(k, a, r)
+
+	sig := make([]byte, SignatureSize)
+	copy(sig, RBytes)
+	var SBytes [32]byte
+	S.BytesInto(&SBytes)
+	copy(sig[32:], SBytes[:])
+	return sig
+}
+
+// Verify reports whether sig is a valid signature of message by pub,
+// using the cofactored equation.
+// Panics if len(pub) is not PublicKeySize
+func Verify(pub PublicKey, message, sig []byte) bool {
+	return VerifyWithMode(pub, message, sig, Cofactored)
+}
+
+// VerifyWithMode reports whether sig is a valid signature of message by
+// pub, using the verification equation selected by mode. Public keys of
+// small order, non-canonical point encodings and S >= r are rejected.
+// Panics if len(pub) is not PublicKeySize
+func VerifyWithMode(pub PublicKey, message, sig []byte, mode Mode) bool {
+	if l := len(pub); l != PublicKeySize {
+		panic("eddsa: bad public key length: " + strconv.Itoa(l))
+	}
+	A, R, S, k, ok := parse(pub, message, sig)
+	if !ok {
+		return false
+	}
+
+	// check = [S]B - [k]A - R, all inputs are public
+	var base jubjub.Point
+	base.SetBase()
+	k.Neg(k)
+	check := jubjub.MultiScalarMultVarTime([]jubjub.Scalar{S, k}, []jubjub.Point{base, A})
+	check.Sub(*check, R)
+
+	switch mode {
+	case Cofactored:
+		check.ClearCofactor(*check)
+	case Cofactorless:
+	default:
+		panic("eddsa: unknown verification mode")
+	}
+	return check.IsIdentity()
+}
+
+// parse decodes the public key and signature, and computes the
+// challenge k. It returns false for signatures of the wrong length,
+// public keys of small order, non-canonical point encodings and S >= r
+func parse(pub PublicKey, message, sig []byte) (A, R jubjub.Point, S, k jubjub.Scalar, ok bool) {
+	if len(sig) != SignatureSize {
+		return
+	}
+	if _, err := A.SetBytes(pub); err != nil || A.IsSmallOrder() {
+		return
+	}
+	if _, err := R.SetBytes(sig[:32]); err != nil {
+		return
+	}
+	if _, err := S.SetCanonicalBytesSlice(sig[32:]); err != nil {
+		return
+	}
+
+	k = challenge(sig[:32], pub, message)
+	return A, R, S, k, true
+}
+
+// nonce returns r = HashToScalar(prefix || M, nonceDST)
+func nonce(prefix, message []byte) jubjub.Scalar {
+	return jubjub.HashToScalar(concat(prefix, message), nonceDST, 1)[0]
+}
+
+// challenge returns k = HashToScalar(R || A || M, challengeDST)
+func challenge(R, A, message []byte) jubjub.Scalar {
+	return jubjub.HashToScalar(concat(R, A, message), challengeDST, 1)[0]
+}
+
+func concat(parts ...[]byte) []byte {
+	var n int
+	for _, p := range parts {
+		n += len(p)
+	}
+	b := make([]byte, 0, n)
+	for _, p := range parts {
+		b = append(b, p...)
+	}
+	return b
+}
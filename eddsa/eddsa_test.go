@@ -0,0 +1,157 @@
+package eddsa
+
+import (
+	"crypto"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	jubjub "github.com/decentralisedkev/go-jubjub"
+	"github.com/stretchr/testify/assert"
+)
+
+// Vectors computed with an independent implementation of the scheme
+func TestVectors(t *testing.T) {
+	long := make([]byte, 300)
+	for i := range long {
+		long[i] = 'a'
+	}
+
+	tests := []struct {
+		seed, pub, sig string
+		msg            []byte
+	}{
+		{"0000000000000000000000000000000000000000000000000000000000000000",
+			"c2fc2d168423567e2935077858740c2d4e4c1ee1776bac7c71747a599c855e04",
+			"ae624d35e434d7829fc642d3ac4c7462e7c892320524bb017fef228ba1c329c567d6c08188a775f9fb4a3a433455c0e94031c312e1d87a9939beea6dc5dba301",
+			[]byte("")},
+		{"000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+			"ccf2108a385c2c98f11f9ff02ef37b15cc3d4399362f00e06d09cad5f5c32a58",
+			"3e665bab8152d54e13da5bd7cf632f07d6029ca383f7e2dfa0b1bc5aca76a1a58e3b0496705e1850306bc10b5fdf0fbaac7e0d646ceeea834f42c9f65598e601",
+			[]byte("abc")},
+		{"19b25856e1c150ca834cffc8b59b23adbd0ec0389e58eb22b3b64768098d002b",
+			"c6c53d8e63da685f33bfb43f944b03a9c99a480b099620a5f08b28e76241b0ed",
+			"8b5ededf8f67c7c1c3846f3ba6c59be64563e0e6c088cdba387d6ee74261e1913f73a003735a66d463527b82d3f306a7f710ccb1c6f854c643817486c969b303",
+			long},
+	}
+
+	for _, tt := range tests {
+		seed, _ := hex.DecodeString(tt.seed)
+		priv := NewKeyFromSeed(seed)
+		pub := priv.Public().(PublicKey)
+		assert.Equal(t, tt.pub, hex.EncodeToString(pub))
+		assert.Equal(t, seed, priv.Seed())
+
+		sig := Sign(priv, tt.msg)
+		assert.Equal(t, tt.sig, hex.EncodeToString(sig))
+		assert.True(t, Verify(pub, tt.msg, sig))
+		assert.True(t, VerifyWithMode(pub, tt.msg, sig, Cofactorless))
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	pub, priv, err := GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+
+	msg := []byte("message")
+	sig, err := priv.Sign(nil, msg, crypto.Hash(0))
+	assert.Nil(t, err)
+	assert.Equal(t, SignatureSize, len(sig))
+	assert.True(t, Verify(pub, msg, sig))
+
+	// Signing is deterministic
+	assert.Equal(t, sig, Sign(priv, msg))
+
+	assert.False(t, Verify(pub, []byte("messagf"), sig))
+	for i := 0; i < SignatureSize; i += 5 {
+		bad := append([]byte(nil), sig...)
+		bad[i] ^= 0x10
+		assert.False(t, Verify(pub, msg, bad))
+	}
+	assert.False(t, Verify(pub, msg, sig[:SignatureSize-1]))
+
+	other, _, err := GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	assert.False(t, Verify(other, msg, sig))
+	assert.False(t, pub.Equal(other))
+	assert.True(t, pub.Equal(priv.Public()))
+
+	_, err = priv.Sign(nil, msg, crypto.SHA512)
+	assert.NotNil(t, err)
+}
+
+// smallOrderPoint returns the point (0, -1) of order 2
+func smallOrderPoint() *jubjub.Point {
+	enc, _ := hex.DecodeString("00000000fffffffffe5bfeff02a4bd5305d8a10908d83933487d9d2953a7ed73")
+	var p jubjub.Point
+	if _, err := p.SetBytes(enc); err != nil {
+		panic(err)
+	}
+	return &p
+}
+
+func TestModes(t *testing.T) {
+	seed := make([]byte, SeedSize)
+	priv := NewKeyFromSeed(seed)
+	pub := priv.Public().(PublicKey)
+	msg := []byte("message")
+
+	// Sign with a nonce point R + T, where T has order 2.
+	// [S]B - [k]A - R' = -T, which only vanishes after clearing the cofactor
+	a, prefix := expandSeed(seed)
+	r := nonce(prefix, msg)
+	var R jubjub.Point
+	R.ScalarMultBase(r)
+	R.Add(R, *smallOrderPoint())
+
+	k := challenge(R.Bytes(), pub, msg)
+	var S jubjub.Scalar
+	S.MulAdd(k, a, r)
+	var SBytes [32]byte
+	S.BytesInto(&SBytes)
+	sig := append(R.Bytes(), SBytes[:]...)
+
+	assert.True(t, VerifyWithMode(pub, msg, sig, Cofactored))
+	assert.False(t, VerifyWithMode(pub, msg, sig, Cofactorless))
+}
+
+func TestStrictEncodings(t *testing.T) {
+	priv := NewKeyFromSeed(make([]byte, SeedSize))
+	pub := priv.Public().(PublicKey)
+	msg := []byte("message")
+	sig := Sign(priv, msg)
+
+	// S = r is the smallest non-canonical scalar
+	bad := append([]byte(nil), sig[:32]...)
+	rBytes, _ := hex.DecodeString("b72cf7d65e0e97d08210c8cc932068a6003b3401013b6706a9af3365eab47d0e")
+	bad = append(bad, rBytes...)
+	assert.False(t, Verify(pub, msg, bad))
+
+	// Small order public keys are rejected
+	assert.False(t, Verify(PublicKey(smallOrderPoint().Bytes()), msg, sig))
+
+	var id jubjub.Point
+	id.Identity()
+	assert.False(t, Verify(PublicKey(id.Bytes()), msg, sig))
+
+	assert.Panics(t, func() { Verify(pub[:31], msg, sig) })
+	assert.Panics(t, func() { NewKeyFromSeed(make([]byte, 31)) })
+}
+
+func BenchmarkSign(b *testing.B) {
+	_, priv, _ := GenerateKey(rand.Reader)
+	msg := []byte("message")
+	for i := 0; i < b.N; i++ {
+		Sign(priv, msg)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	pub, priv, _ := GenerateKey(rand.Reader)
+	msg := []byte("message")
+	sig := Sign(priv, msg)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Verify(pub, msg, sig)
+	}
+}
@@ -0,0 +1,93 @@
+package elgamal
+
+import (
+	"errors"
+	"math"
+
+	jubjub "github.com/decentralisedkev/go-jubjub"
+)
+
+// batchSize is the number of points encoded at once with
+// jubjub.BatchBytes, which shares one field inversion between them
+const batchSize = 1024
+
+// Table solves discrete logarithms [v]G = M for v in [0, max] with
+// the baby-step giant-step algorithm. Building it takes about sqrt(max)
+// point additions and it can be reused for any number of decryptions.
+// Variable time, so it must only be used on values that are public to
+// the holder of the private key
+type Table struct {
+	max uint64
+
+	// m is the number of baby steps
+	m uint64
+	// baby maps the encoding of [j]G to j for j in [0, m)
+	baby map[[32]byte]uint64
+	// giant is -[m]G
+	giant jubjub.Point
+}
+
+// NewTable returns a table for the logarithms in [0, max]
+func NewTable(max uint64) *Table {
+	m := uint64(math.Sqrt(float64(max))) + 1
+
+	t := &Table{max: max, m: m, baby: make(map[[32]byte]uint64, m)}
+
+	var g, p jubjub.Point
+	g.SetBase()
+	p.Identity()
+
+	batch := make([]jubjub.Point, 0, batchSize)
+	for j := uint64(0); j < m; j += uint64(len(batch)) {
+		batch = batch[:0]
+		for k := j; k < m && len(batch) < batchSize; k++ {
+			batch = append(batch, p)
+			p.Add(p, g)
+		}
+
+		for k, enc := range jubjub.BatchBytes(batch) {
+			var key [32]byte
+			copy(key[:], enc)
+			t.baby[key] = j + uint64(k)
+		}
+	}
+
+	// p is now [m]G
+	t.giant.Neg(p)
+	return t
+}
+
+// Max returns the largest logarithm found by t
+func (t *Table) Max() uint64 {
+	return t.max
+}
+
+// Log returns v such that [v]G = p. It returns an error if there is no
+// such v in [0, t.Max()]
+func (t *Table) Log(p *jubjub.Point) (uint64, error) {
+	steps := t.max/t.m + 1
+	gamma := *p
+
+	// The giant steps are taken a batch at a time, so up to batchSize-1
+	// of them are wasted when the value is found early in a batch
+	batch := make([]jubjub.Point, 0, batchSize)
+	for i := uint64(0); i < steps; i += uint64(len(batch)) {
+		batch = batch[:0]
+		for k := i; k < steps && len(batch) < batchSize; k++ {
+			batch = append(batch, gamma)
+			gamma.Add(gamma, t.giant)
+		}
+
+		for k, enc := range jubjub.BatchBytes(batch) {
+			var key [32]byte
+			copy(key[:], enc)
+			if j, ok := t.baby[key]; ok {
+				if v := (i+uint64(k))*t.m + j; v <= t.max {
+					return v, nil
+				}
+				return 0, errors.New("elgamal: value is out of range")
+			}
+		}
+	}
+	return 0, errors.New("elgamal: value is out of range")
+}
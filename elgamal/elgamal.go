@@ -0,0 +1,176 @@
+// Package elgamal implements ElGamal encryption over the prime order
+// subgroup of Jubjub. Points are encrypted directly, and small integers
+// are encrypted in the exponent so that ciphertexts can be added
+// homomorphically, at the cost of a bounded discrete logarithm on decryption
+package elgamal
+
+import (
+	"errors"
+	"io"
+
+	jubjub "github.com/decentralisedkev/go-jubjub"
+)
+
+// CiphertextSize is the size of an encoded ciphertext in bytes
+const CiphertextSize = 64
+
+// PrivateKey is an ElGamal decryption key x
+type PrivateKey struct {
+	x   jubjub.Scalar
+	pub PublicKey
+}
+
+// PublicKey is an ElGamal encryption key H = [x]G, where G is the
+// base point
+type PublicKey struct {
+	h jubjub.Point
+}
+
+// Ciphertext is the encryption (C1, C2) = ([r]G, M + [r]H) of a point M
+type Ciphertext struct {
+	C1, C2 jubjub.Point
+}
+
+// GenerateKey returns a new private key using randomness from rand
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	x, err := randomScalar(rand)
+	if err != nil {
+		return nil, err
+	}
+
+	k := &PrivateKey{x: x}
+	k.pub.h.ScalarMultBase(x)
+	return k, nil
+}
+
+// Public returns the public key of k
+func (k *PrivateKey) Public() *PublicKey {
+	pub := k.pub
+	return &pub
+}
+
+// NewPublicKey decodes a public key. It returns an error if b is not
+// the encoding of a point in the prime order subgroup
+func NewPublicKey(b []byte) (*PublicKey, error) {
+	var pub PublicKey
+	if _, err := pub.h.SetBytesSubgroup(b); err != nil {
+		return nil, err
+	}
+	return &pub, nil
+}
+
+// Bytes returns the encoding of the public key
+func (pub *PublicKey) Bytes() []byte {
+	return pub.h.Bytes()
+}
+
+// Encrypt encrypts the point m to pub, using randomness from rand
+func (pub *PublicKey) Encrypt(rand io.Reader, m *jubjub.Point) (*Ciphertext, error) {
+	r, err := randomScalar(rand)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Ciphertext{}
+	c.C1.ScalarMultBase(r)
+	c.C2.ScalarMult(r, &pub.h)
+	c.C2.Add(c.C2, *m)
+	return c, nil
+}
+
+// EncryptValue encrypts the integer v as the point [v]G, using
+// randomness from rand. Such ciphertexts can be added and subtracted,
+// and decrypted with DecryptValue as long as the result stays small
+func (pub *PublicKey) EncryptValue(rand io.Reader, v uint64) (*Ciphertext, error) {
+	var m jubjub.Point
+	m.ScalarMultBase(scalarFromUint64(v))
+	return pub.Encrypt(rand, &m)
+}
+
+// Rerandomize returns a fresh encryption of the same plaintext as c,
+// which cannot be linked to c without the private key
+func (pub *PublicKey) Rerandomize(rand io.Reader, c *Ciphertext) (*Ciphertext, error) {
+	var id jubjub.Point
+	id.Identity()
+
+	zero, err := pub.Encrypt(rand, &id)
+	if err != nil {
+		return nil, err
+	}
+	return zero.Add(zero, c), nil
+}
+
+// Decrypt returns the point encrypted by c, M = C2 - [x]C1
+func (k *PrivateKey) Decrypt(c *Ciphertext) *jubjub.Point {
+	var m jubjub.Point
+	m.ScalarMult(k.x, &c.C1)
+	m.Sub(c.C2, m)
+	return &m
+}
+
+// DecryptValue returns the integer v encrypted by c, provided that v is
+// within the bound of t. It returns an error otherwise
+func (k *PrivateKey) DecryptValue(c *Ciphertext, t *Table) (uint64, error) {
+	return t.Log(k.Decrypt(c))
+}
+
+// Add sets c = a + b, an encryption of the sum of the plaintexts
+func (c *Ciphertext) Add(a, b *Ciphertext) *Ciphertext {
+	c.C1.Add(a.C1, b.C1)
+	c.C2.Add(a.C2, b.C2)
+	return c
+}
+
+// Sub sets c = a - b, an encryption of the difference of the plaintexts
+func (c *Ciphertext) Sub(a, b *Ciphertext) *Ciphertext {
+	c.C1.Sub(a.C1, b.C1)
+	c.C2.Sub(a.C2, b.C2)
+	return c
+}
+
+// ScalarMul sets c = [s]a, an encryption of the plaintext of a multiplied by s
+func (c *Ciphertext) ScalarMul(a *Ciphertext, s jubjub.Scalar) *Ciphertext {
+	c.C1.ScalarMult(s, &a.C1)
+	c.C2.ScalarMult(s, &a.C2)
+	return c
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (c *Ciphertext) MarshalBinary() ([]byte, error) {
+	return append(c.C1.Bytes(), c.C2.Bytes()...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. Both points must
+// be in the prime order subgroup
+func (c *Ciphertext) UnmarshalBinary(data []byte) error {
+	if len(data) != CiphertextSize {
+		return errors.New("elgamal: invalid ciphertext size")
+	}
+
+	var c1, c2 jubjub.Point
+	if _, err := c1.SetBytesSubgroup(data[:32]); err != nil {
+		return err
+	}
+	if _, err := c2.SetBytesSubgroup(data[32:]); err != nil {
+		return err
+	}
+
+	c.C1, c.C2 = c1, c2
+	return nil
+}
+
+func randomScalar(rand io.Reader) (jubjub.Scalar, error) {
+	var buf [64]byte
+	var s jubjub.Scalar
+	if _, err := io.ReadFull(rand, buf[:]); err != nil {
+		return s, err
+	}
+	s.FromBytes(buf)
+	return s, nil
+}
+
+func scalarFromUint64(v uint64) jubjub.Scalar {
+	var s jubjub.Scalar
+	s.SetUint64(v)
+	return s
+}
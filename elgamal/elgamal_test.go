@@ -0,0 +1,138 @@
+package elgamal
+
+import (
+	"crypto/rand"
+	"testing"
+
+	jubjub "github.com/decentralisedkev/go-jubjub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptPoint(t *testing.T) {
+	k, err := GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+
+	m := jubjub.HashToCurve([]byte("message"), []byte("elgamal-test"))
+	c, err := k.Public().Encrypt(rand.Reader, m)
+	assert.Nil(t, err)
+	assert.True(t, k.Decrypt(c).Equal(*m))
+
+	// Another key decrypts to something else
+	other, err := GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	assert.False(t, other.Decrypt(c).Equal(*m))
+}
+
+func TestHomomorphism(t *testing.T) {
+	k, err := GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	pub := k.Public()
+	table := NewTable(1 << 20)
+
+	a, err := pub.EncryptValue(rand.Reader, 123456)
+	assert.Nil(t, err)
+	b, err := pub.EncryptValue(rand.Reader, 654321)
+	assert.Nil(t, err)
+
+	var c Ciphertext
+	v, err := k.DecryptValue(c.Add(a, b), table)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(777777), v)
+
+	v, err = k.DecryptValue(c.Sub(b, a), table)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(530865), v)
+
+	v, err = k.DecryptValue(c.ScalarMul(a, scalarFromUint64(3)), table)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(370368), v)
+
+	// a - b is negative, so it wraps around to r - 530865
+	_, err = k.DecryptValue(c.Sub(a, b), table)
+	assert.NotNil(t, err)
+}
+
+func TestRerandomize(t *testing.T) {
+	k, err := GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	pub := k.Public()
+
+	c, err := pub.EncryptValue(rand.Reader, 42)
+	assert.Nil(t, err)
+	c2, err := pub.Rerandomize(rand.Reader, c)
+	assert.Nil(t, err)
+
+	assert.False(t, c.C1.Equal(c2.C1))
+	assert.False(t, c.C2.Equal(c2.C2))
+	assert.True(t, k.Decrypt(c).Equal(*k.Decrypt(c2)))
+}
+
+func TestTable(t *testing.T) {
+	var p jubjub.Point
+	for _, max := range []uint64{0, 1, 2, 15, 16, 17, 1000} {
+		table := NewTable(max)
+		for _, v := range []uint64{0, 1, max / 2, max - 1, max} {
+			if v > max {
+				continue
+			}
+			p.ScalarMultBase(scalarFromUint64(v))
+			got, err := table.Log(&p)
+			assert.Nil(t, err, "max = %d, v = %d", max, v)
+			assert.Equal(t, v, got)
+		}
+
+		p.ScalarMultBase(scalarFromUint64(max + 1))
+		_, err := table.Log(&p)
+		assert.NotNil(t, err, "max = %d", max)
+	}
+}
+
+func TestCiphertextEncoding(t *testing.T) {
+	k, err := GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+
+	pub, err := NewPublicKey(k.Public().Bytes())
+	assert.Nil(t, err)
+
+	c, err := pub.EncryptValue(rand.Reader, 7)
+	assert.Nil(t, err)
+
+	data, err := c.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Equal(t, CiphertextSize, len(data))
+
+	var c2 Ciphertext
+	assert.Nil(t, c2.UnmarshalBinary(data))
+	v, err := k.DecryptValue(&c2, NewTable(10))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(7), v)
+
+	assert.NotNil(t, c2.UnmarshalBinary(data[:63]))
+
+	// A point outside the prime order subgroup is rejected
+	bad := append([]byte(nil), data...)
+	copy(bad[32:], smallOrderEncoding)
+	assert.NotNil(t, c2.UnmarshalBinary(bad))
+}
+
+// smallOrderEncoding is the encoding of (0, -1), which has order 2
+var smallOrderEncoding = []byte{
+	0x00, 0x00, 0x00, 0x00, 0xff, 0xff, 0xff, 0xff, 0xfe, 0x5b, 0xfe, 0xff, 0x02, 0xa4, 0xbd, 0x53,
+	0x05, 0xd8, 0xa1, 0x09, 0x08, 0xd8, 0x39, 0x33, 0x48, 0x7d, 0x9d, 0x29, 0x53, 0xa7, 0xed, 0x73,
+}
+
+func BenchmarkNewTable(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewTable(1 << 32)
+	}
+}
+
+func BenchmarkDecryptValue(b *testing.B) {
+	k, _ := GenerateKey(rand.Reader)
+	c, _ := k.Public().EncryptValue(rand.Reader, 1<<31+12345)
+	table := NewTable(1 << 32)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		k.DecryptValue(c, table)
+	}
+}
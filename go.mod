@@ -1,12 +1,17 @@
 module github.com/decentralisedkev/go-jubjub
 
 require (
+	github.com/stretchr/testify v1.3.0
+	golang.org/x/crypto v0.0.0-20190103213133-ff983b9c42bc
+	golang.org/x/sys v0.0.0-20190124100055-b90733256f2e
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
 	github.com/google/pprof v0.0.0-20190109223431-e84dfd68c163 // indirect
 	github.com/ianlancetaylor/demangle v0.0.0-20181102032728-5e5cf60278f6 // indirect
-	github.com/stretchr/testify v1.3.0
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/arch v0.0.0-20181203225421-5a4828bb7045 // indirect
-	golang.org/x/crypto v0.0.0-20190103213133-ff983b9c42bc
-	golang.org/x/sys v0.0.0-20190124100055-b90733256f2e // indirect
 )
 
-go 1.13
+go 1.18
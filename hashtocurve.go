@@ -0,0 +1,52 @@
+package jubjub
+
+import (
+	fq "github.com/decentralisedkev/go-jubjub/internal/Fq"
+)
+
+const (
+	// HashToCurveSuite is the RFC 9380 suite identifier of HashToCurve
+	HashToCurveSuite = "jubjub_XMD:SHA-512_ELL2_RO_"
+	// EncodeToCurveSuite is the RFC 9380 suite identifier of EncodeToCurve
+	EncodeToCurveSuite = "jubjub_XMD:SHA-512_ELL2_NU_"
+)
+
+// HashToCurve hashes msg to a point in the prime order subgroup, using
+// the random oracle encoding of RFC 9380 with Elligator 2.
+// dst is the domain separation tag and should be unique to the protocol.
+// Runs in constant time with respect to msg
+func HashToCurve(msg, dst []byte) *Point {
+	u := hashToFieldQ(msg, dst, 2)
+
+	var p, q Point
+	p.ep().MapToCurve(u[0])
+	q.ep().MapToCurve(u[1])
+	p.Add(p, q)
+
+	return p.ClearCofactor(p)
+}
+
+// EncodeToCurve hashes msg to a point in the prime order subgroup,
+// using the nonuniform encoding of RFC 9380 with Elligator 2. It is
+// cheaper than HashToCurve but the output distribution is only
+// a subset of the group, see RFC 9380 section 10.4
+func EncodeToCurve(msg, dst []byte) *Point {
+	u := hashToFieldQ(msg, dst, 1)
+
+	var p Point
+	p.ep().MapToCurve(u[0])
+
+	return p.ClearCofactor(p)
+}
+
+// hashToFieldQ implements hash_to_field from RFC 9380, section 5.2,
+// for the base field of Jubjub with expand_message_xmd and SHA-512
+func hashToFieldQ(msg, dst []byte, count int) []fq.FieldQ {
+	wide := hashToField(ExpandSHA512, msg, dst, count)
+
+	u := make([]fq.FieldQ, count)
+	for i := range u {
+		u[i].FromBytes(wide[i])
+	}
+	return u
+}
@@ -0,0 +1,67 @@
+package jubjub
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Vectors computed with an independent implementation of RFC 9380
+// for the jubjub_XMD:SHA-512_ELL2 suites
+func TestHashToCurveVectors(t *testing.T) {
+	long := make([]byte, 512)
+	for i := range long {
+		long[i] = 'a'
+	}
+
+	tests := []struct {
+		msg     []byte
+		ro, enc string
+	}{
+		{[]byte(""),
+			"1704d5133b5530a46aba207b6cf0ececb748dcbb0f94c5482a69375f6ca5f05e",
+			"290a4ffe16a34cc39eb13915fb122e8be214e7e544fda04ee567d767c6bae233"},
+		{[]byte("abc"),
+			"3d82e878b940c28388e835917f7be72a7d7041d09abe14ef3995c968645a2261",
+			"3e1310f10df397aa4c46efa94495b6e52459dd12787bed683f6f954b094e6e6d"},
+		{[]byte("abcdef0123456789"),
+			"04e8697da6f46febdbafce02999bc596ad9ccb267e235d93a1312855067826ef",
+			"a7b6bca51c67c8455341c5fe7cceea2483e5e9e7869ee1b1c3c4032deb6066b0"},
+		{long,
+			"f768e2a4900af49316df580ec0d3a74a78ed5e5f3f2236bd686b369df0c4652d",
+			"49c96b05b4f141022163ad545b83ac268078f4ab4c1b854e6276c1412701be4c"},
+	}
+
+	roDST := []byte("QUUX-V01-CS02-with-" + HashToCurveSuite)
+	nuDST := []byte("QUUX-V01-CS02-with-" + EncodeToCurveSuite)
+
+	for _, tt := range tests {
+		p := HashToCurve(tt.msg, roDST)
+		assert.Equal(t, tt.ro, hex.EncodeToString(p.Bytes()))
+		assert.True(t, p.IsPrimeOrder())
+
+		p = EncodeToCurve(tt.msg, nuDST)
+		assert.Equal(t, tt.enc, hex.EncodeToString(p.Bytes()))
+		assert.True(t, p.IsPrimeOrder())
+	}
+}
+
+func TestHashToCurveDomainSeparation(t *testing.T) {
+	msg := []byte("message")
+
+	a := HashToCurve(msg, []byte("protocol-a"))
+	b := HashToCurve(msg, []byte("protocol-b"))
+	assert.False(t, a.Equal(*b))
+
+	c := HashToCurve(msg, []byte("protocol-a"))
+	assert.True(t, a.Equal(*c))
+}
+
+func BenchmarkHashToCurve(b *testing.B) {
+	msg := []byte("message")
+	dst := []byte("QUUX-V01-CS02-with-" + HashToCurveSuite)
+	for i := 0; i < b.N; i++ {
+		HashToCurve(msg, dst)
+	}
+}
@@ -0,0 +1,94 @@
+package jubjub
+
+import (
+	"github.com/decentralisedkev/go-jubjub/internal/expand"
+)
+
+// Expander is an expand_message function from RFC 9380, section 5.3,
+// which expands msg and dst into lenInBytes uniformly random bytes
+type Expander interface {
+	Expand(msg, dst []byte, lenInBytes int) ([]byte, error)
+}
+
+var (
+	// ExpandSHA256 is expand_message_xmd with SHA-256
+	ExpandSHA256 Expander = expand.SHA256
+	// ExpandSHA512 is expand_message_xmd with SHA-512
+	ExpandSHA512 Expander = expand.SHA512
+	// ExpandSHAKE128 is expand_message_xof with SHAKE128
+	ExpandSHAKE128 Expander = expand.SHAKE128
+	// ExpandSHAKE256 is expand_message_xof with SHAKE256
+	ExpandSHAKE256 Expander = expand.SHAKE256
+	// ExpandBLAKE2s is expand_message_xof with BLAKE2Xs
+	ExpandBLAKE2s Expander = expand.BLAKE2s
+	// ExpandBLAKE2b is expand_message_xof with BLAKE2Xb
+	ExpandBLAKE2b Expander = expand.BLAKE2b
+)
+
+// HashOption configures hashing to a field
+type HashOption func(*hashConfig)
+
+type hashConfig struct {
+	expander Expander
+}
+
+// WithExpander selects the expand_message function. The default is
+// expand_message_xmd with SHA-512
+func WithExpander(e Expander) HashOption {
+	return func(c *hashConfig) {
+		c.expander = e
+	}
+}
+
+func newHashConfig(opts []HashOption) hashConfig {
+	c := hashConfig{expander: ExpandSHA512}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// hashToFieldLen is the number of bytes expanded per field element,
+// L = ceil((ceil(log2(p)) + k) / 8) with k = 128. It is the same for
+// the base field and the scalar field
+const hashToFieldLen = 48
+
+// HashToScalar hashes msg into count scalars following hash_to_field
+// from RFC 9380, section 5.2. dst is the domain separation tag and
+// should be unique to the protocol, so that different protocols
+// hashing the same message get unrelated scalars.
+// Panics if the expander cannot produce count scalars, for instance
+// if count > 170 with ExpandSHA256
+func HashToScalar(msg, dst []byte, count int, opts ...HashOption) []Scalar {
+	c := newHashConfig(opts)
+
+	wide := hashToField(c.expander, msg, dst, count)
+	s := make([]Scalar, count)
+	for i := range s {
+		s[i].FromBytes(wide[i])
+	}
+	return s
+}
+
+// hashToField expands msg and dst into count big endian integers of
+// hashToFieldLen bytes each, returned in the 64 byte little endian
+// form taken by FromBytes for reduction into the field
+func hashToField(e Expander, msg, dst []byte, count int) [][64]byte {
+	if count < 0 {
+		panic("jubjub: negative count")
+	}
+
+	uniform, err := e.Expand(msg, dst, count*hashToFieldLen)
+	if err != nil {
+		panic("jubjub: " + err.Error())
+	}
+
+	wide := make([][64]byte, count)
+	for i := range wide {
+		be := uniform[i*hashToFieldLen : (i+1)*hashToFieldLen]
+		for j, b := range be {
+			wide[i][hashToFieldLen-1-j] = b
+		}
+	}
+	return wide
+}
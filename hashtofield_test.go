@@ -0,0 +1,76 @@
+package jubjub
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Vectors computed with an independent implementation of hash_to_field
+// from RFC 9380 for the scalar field, with L = 48
+func TestHashToScalarVectors(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-jubjub_XMD:SHA-512_SCALAR_")
+
+	tests := []struct {
+		name     string
+		opts     []HashOption
+		msg      string
+		expected []string
+	}{
+		{"default", nil, "abc", []string{
+			"c6e64bc094e01ced1c69471e2ad8545521bd14f42d68b8dcf6f7cd467222e005",
+			"73856b63326e6c51c9447f22fdb31109be2c6561e7d3feb1796874fa91e80e03",
+		}},
+		{"default empty", nil, "", []string{
+			"f27f14ab57df463d8904cb999dc6b418ffba75fc74aa61afba88b4be2b230603",
+		}},
+		{"sha256", []HashOption{WithExpander(ExpandSHA256)}, "abc", []string{
+			"827f3039665c9b69b95046a2aef5a7002f6883a6a93f66e4fe0e5eb0cac02402",
+			"a9bfecab620cb406f66b5ee7eaeb5b58c6048ab4fd33a4806cfa749c76571906",
+		}},
+		{"shake128", []HashOption{WithExpander(ExpandSHAKE128)}, "abc", []string{
+			"bf0ee4d3c2d20c93ca1dfe913c978d5175d5d4d3adc783e8e3c20e57e4a42302",
+			"dff51fb7a46475735ca12eb0200e6fd247e805da5b98bb39c631f34ebc16070b",
+		}},
+		{"shake256", []HashOption{WithExpander(ExpandSHAKE256)}, "abc", []string{
+			"31673d038ddfce8a0602dd36b451c78dbfd2cd82f5bd6f9ff8720f6d10870102",
+		}},
+	}
+
+	for _, tt := range tests {
+		s := HashToScalar([]byte(tt.msg), dst, len(tt.expected), tt.opts...)
+		assert.Equal(t, len(tt.expected), len(s), tt.name)
+		for i := range s {
+			var buf [32]byte
+			s[i].BytesInto(&buf)
+			assert.Equal(t, tt.expected[i], hex.EncodeToString(buf[:]), tt.name)
+		}
+	}
+}
+
+func TestHashToScalarDomainSeparation(t *testing.T) {
+	msg := []byte("message")
+
+	for _, e := range []Expander{ExpandSHA256, ExpandSHA512, ExpandSHAKE128, ExpandSHAKE256, ExpandBLAKE2s, ExpandBLAKE2b} {
+		a := HashToScalar(msg, []byte("protocol-a"), 3, WithExpander(e))
+		b := HashToScalar(msg, []byte("protocol-b"), 3, WithExpander(e))
+		c := HashToScalar(msg, []byte("protocol-a"), 3, WithExpander(e))
+
+		assert.Equal(t, a, c)
+		for i := range a {
+			assert.NotEqual(t, a[i], b[i])
+		}
+		assert.NotEqual(t, a[0], a[1])
+	}
+}
+
+func TestHashToScalarCount(t *testing.T) {
+	assert.Equal(t, 0, len(HashToScalar(nil, []byte("DST"), 0)))
+
+	// ExpandSHA256 produces at most 255 * 32 bytes
+	assert.Equal(t, 170, len(HashToScalar(nil, []byte("DST"), 170, WithExpander(ExpandSHA256))))
+	assert.Panics(t, func() {
+		HashToScalar(nil, []byte("DST"), 171, WithExpander(ExpandSHA256))
+	})
+}
@@ -66,6 +66,15 @@ func (f *FieldQ) FromBytes(byt [64]byte) *FieldQ {
 	return f
 }
 
+// SetBytes sets f to the canonical little endian encoding in buf.
+// Returns an error if the value is not less than q
+func (f *FieldQ) SetBytes(buf *[32]byte) (*FieldQ, error) {
+	if !f.Field.SetCanonicalBytes(buf, INV, qMod, r2) {
+		return f, errors.New("field element is not canonical")
+	}
+	return f, nil
+}
+
 func (f *FieldQ) PowVarTime(b [4]uint64) *FieldQ {
 	f.Field.PowVarTime(b, montR, INV, qMod)
 	return f
@@ -137,6 +146,52 @@ func (f *FieldQ) SqrtVarTime() (*FieldQ, error) {
 	return f, nil
 }
 
+// Sqrt sets f to a square root of a and returns 1 if a is a square.
+// Otherwise f is set to garbage and 0 is returned. Runs in constant
+// time using the Tonelli-Shanks variant from RFC 9380, appendix I.4
+func (f *FieldQ) Sqrt(a FieldQ) uint64 {
+	var z, t, b, c, tmp, one FieldQ
+	one.SetOne()
+
+	// z = a^((t - 1) / 2), where q - 1 = 2^S * t
+	z.Set(a)
+	z.PowVarTime([4]uint64{0x7fff2dff7fffffff, 0x04d0ec02a9ded201, 0x94cebea4199cec04, 0x0000000039f6d3a9})
+
+	t.Square(z)
+	t.Mul(t, a) // a^t
+	z.Mul(z, a) // a^((t + 1) / 2)
+	b.Set(t)
+	c.Set(FieldQ{rootOfUnity})
+
+	for i := S; i >= 2; i-- {
+		for j := uint32(1); j <= i-2; j++ {
+			b.Square(b)
+		}
+		e := field.ConstantTimeEq(b.Field, one.Field)
+
+		tmp.Mul(z, c)
+		z.CondSel(z.Field, tmp.Field, e)
+		c.Square(c)
+		tmp.Mul(t, c)
+		t.CondSel(t.Field, tmp.Field, e)
+		b.Set(t)
+	}
+
+	tmp.Square(z)
+	isSquare := field.ConstantTimeEq(tmp.Field, a.Field)
+
+	f.Set(z)
+	return isSquare
+}
+
+// Sgn0 returns the parity of the canonical representation of f,
+// as defined in RFC 9380, section 4.1
+func (f *FieldQ) Sgn0() uint64 {
+	var buf [32]byte
+	f.BytesInto(&buf)
+	return uint64(buf[0] & 1)
+}
+
 // Rand returns a random field element
 func (f *FieldQ) Rand() *FieldQ {
 	var buf [64]byte
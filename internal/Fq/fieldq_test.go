@@ -2,6 +2,7 @@ package fq
 
 import (
 	"bufio"
+	"encoding/binary"
 	"encoding/hex"
 	"os"
 	"strconv"
@@ -245,3 +246,58 @@ func TestInvKO(t *testing.T) {
 	inv = -inv
 	assert.Equal(t, inv, INV)
 }
+
+func TestSetBytesKO(t *testing.T) {
+	var a, b FieldQ
+	var buf [32]byte
+	for i := 0; i < 100; i++ {
+		a.Rand()
+		a.BytesInto(&buf)
+		_, err := b.SetBytes(&buf)
+		assert.Equal(t, nil, err)
+		assert.Equal(t, a.Field, b.Field)
+	}
+
+	// q itself is not canonical
+	var qBuf [32]byte
+	binary.LittleEndian.PutUint64(qBuf[0:8], qMod[0])
+	binary.LittleEndian.PutUint64(qBuf[8:16], qMod[1])
+	binary.LittleEndian.PutUint64(qBuf[16:24], qMod[2])
+	binary.LittleEndian.PutUint64(qBuf[24:32], qMod[3])
+	_, err := b.SetBytes(&qBuf)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestSqrtKO(t *testing.T) {
+	var a, s, r, five FieldQ
+	five.FromU64(5) // 5 is not a square mod q
+
+	for i := 0; i < 100; i++ {
+		a.Rand()
+		s.Square(a)
+
+		assert.Equal(t, uint64(1), r.Sqrt(s))
+		r.Square(r)
+		assert.Equal(t, s.Field, r.Field)
+
+		s.Mul(s, five)
+		assert.Equal(t, uint64(0), r.Sqrt(s))
+	}
+
+	a.SetZero()
+	assert.Equal(t, uint64(1), r.Sqrt(a))
+	assert.Equal(t, true, r.IsZero())
+}
+
+func TestSgn0KO(t *testing.T) {
+	var a FieldQ
+	a.FromU64(2)
+	assert.Equal(t, uint64(0), a.Sgn0())
+
+	// -2 = q - 2 is odd
+	a.Neg(a)
+	assert.Equal(t, uint64(1), a.Sgn0())
+
+	a.SetZero()
+	assert.Equal(t, uint64(0), a.Sgn0())
+}
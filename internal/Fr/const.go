@@ -1,20 +1,34 @@
 package fr
 
-var zero = Fr{0, 0, 0, 0}
+import "github.com/decentralisedkev/go-jubjub/internal/field"
 
 // INV = -(r^{-1} mod 2^64) mod 2^64
 const INV uint64 = 0x1ba3a358ef788ef9
 
-// R = 2^256 mod r
-// the montgomoery modulus
-var R = Fr{0x25f80bb3b99607d9, 0xf315d62f66b6e750, 0x932514eeeb8814f4, 0x09a6fc6f479155c6}
+var (
+	// rMod is the modulus, the order of the prime order subgroup of Jubjub
+	// r = 0x0e7db4ea6533afa906673b0101343b00a6682093ccc81082d0970e5ed6f72cb7
+	rMod = field.Field{0xd0970e5ed6f72cb7, 0xa6682093ccc81082, 0x06673b0101343b00, 0x0e7db4ea6533afa9}
 
-// R2 (Rsquared) = 2^512 mod r
-var R2 = Fr{0x67719aa495e57731, 0x51b0cef09ce3fc26, 0x69dab7fac026e9a5, 0x04f6547b8d127688}
+	// R = 2^256 mod r
+	// the montgomery modulus, which is also the Montgomery form of one
+	montR = field.Field{0x25f80bb3b99607d9, 0xf315d62f66b6e750, 0x932514eeeb8814f4, 0x09a6fc6f479155c6}
 
-// r is modulus in Fr
-// r = 0x0e7db4ea6533afa906673b0101343b00a6682093ccc81082d0970e5ed6f72cb7
-var r = Fr{0xd0970e5ed6f72cb7, 0xa6682093ccc81082, 0x06673b0101343b00, 0x0e7db4ea6533afa9}
+	// R2 = 2^512 mod r
+	r2 = field.Field{0x67719aa495e57731, 0x51b0cef09ce3fc26, 0x69dab7fac026e9a5, 0x04f6547b8d127688}
 
-// NEG1 = -R = -(2^256 mod r) mod r
-var NEG1 = Fr{0xaa9f02ab1d6124de, 0xb3524a6466112932, 0x7342261215ac260b, 0x4d6b87b1da259e2}
+	// R3 = 2^768 mod r
+	r3 = field.Field{0xe0d6c6563d830544, 0x323e3883598d0f85, 0xf0fea3004c2e2ba8, 0x05874f84946737ec}
+
+	// NEG1 = -R = -(2^256 mod r) mod r, the Montgomery form of -1
+	NEG1 = field.Field{0xaa9f02ab1d6124de, 0xb3524a6466112932, 0x7342261215ac260b, 0x04d6b87b1da259e2}
+
+	// rMinus2 = r - 2 is the exponent of inversion
+	rMinus2 = [4]uint64{0xd0970e5ed6f72cb5, 0xa6682093ccc81082, 0x06673b0101343b00, 0x0e7db4ea6533afa9}
+
+	// rMinus1Div2 = (r - 1) / 2 is the exponent of the Legendre symbol
+	rMinus1Div2 = [4]uint64{0x684b872f6b7b965b, 0x53341049e6640841, 0x83339d80809a1d80, 0x073eda753299d7d4}
+
+	// rPlus1Div4 = (r + 1) / 4 is the exponent of the square root, as r = 3 mod 4
+	rPlus1Div4 = [4]uint64{0xb425c397b5bdcb2e, 0x299a0824f3320420, 0x4199cec0404d0ec0, 0x039f6d3a994cebea}
+)
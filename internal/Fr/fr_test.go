@@ -0,0 +1,114 @@
+package fr
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/decentralisedkev/go-jubjub/internal/field"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromBytesWide(t *testing.T) {
+	var f FieldR
+
+	var max [64]byte
+	for i := range max {
+		max[i] = 0xff
+	}
+	f.FromBytes(max)
+	// (2^512 - 1) mod r
+	assert.Equal(t, "3077e595a49a716726fce39cf0ceb051a5e926c0fab7da698876128d7b54f604", hex.EncodeToString(f.IntoBytes()))
+
+	var pow [64]byte
+	pow[32] = 1
+	f.FromBytes(pow)
+	// 2^256 mod r
+	assert.Equal(t, "d90796b9b30bf82550e7b6662fd615f3f41488ebee142593c65591476ffca609", hex.EncodeToString(f.IntoBytes()))
+}
+
+func TestConstants(t *testing.T) {
+	var one, f FieldR
+	one.SetOne()
+
+	f.FromU64(1)
+	assert.Equal(t, one, f)
+
+	f.Add(FieldR{NEG1}, one)
+	assert.True(t, f.IsZero())
+
+	// Montgomery multiplication divides by R, so R2 * R2 = R3
+	f.Mul(FieldR{r2}, FieldR{r2})
+	assert.Equal(t, FieldR{r3}, f)
+}
+
+func TestSetBytes(t *testing.T) {
+	var f FieldR
+
+	// 1 is canonical
+	buf := [32]byte{1}
+	_, err := f.SetBytes(&buf)
+	assert.Nil(t, err)
+	assert.Equal(t, FieldR{montR}, f)
+
+	// r itself is rejected, and f is left unchanged
+	before := f
+	for i := range rMod {
+		for j := 0; j < 8; j++ {
+			buf[8*i+j] = byte(rMod[i] >> uint(8*j))
+		}
+	}
+	_, err = f.SetBytes(&buf)
+	assert.NotNil(t, err)
+	assert.Equal(t, before, f)
+}
+
+func TestReduce(t *testing.T) {
+	var f, one FieldR
+	one.SetOne()
+
+	// r + 1 reduces to 1, in the raw representation
+	f.Reduce(FieldR{field.Field{rMod[0] + 1, rMod[1], rMod[2], rMod[3]}})
+	assert.Equal(t, FieldR{field.Field{1, 0, 0, 0}}, f)
+
+	f.Reduce(one)
+	assert.Equal(t, one, f)
+}
+
+func TestInvertSqrt(t *testing.T) {
+	var a, inv, prod, one, root, sq FieldR
+	one.SetOne()
+
+	for i := uint64(1); i < 32; i++ {
+		a.FromU64(i)
+		inv.Invert(a)
+		prod.Mul(a, inv)
+		assert.Equal(t, one, prod)
+
+		sq.Square(a)
+		assert.Equal(t, uint64(1), root.Sqrt(sq))
+		var neg FieldR
+		neg.Neg(a)
+		assert.True(t, root == a || root == neg)
+	}
+
+	// 3 is the smallest non-square
+	a.FromU64(3)
+	assert.Equal(t, uint64(0), root.Sqrt(a))
+}
+
+func TestCmpLegendre(t *testing.T) {
+	var a, b FieldR
+	a.FromU64(3)
+	b.FromU64(4)
+
+	// The Montgomery forms of 3 and 4 are in the opposite order
+	assert.Equal(t, int8(1), field.Cmp(a.Field, b.Field))
+	assert.Equal(t, int8(-1), Cmp(a, b))
+	assert.Equal(t, int8(0), Cmp(a, a))
+
+	assert.Equal(t, -1, a.LegendreSymbolVarTime())
+	assert.Equal(t, 1, b.LegendreSymbolVarTime())
+
+	a.ConditionalSet(b, 1)
+	assert.Equal(t, b, a)
+}
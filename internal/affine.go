@@ -1,6 +1,8 @@
 package curve
 
 import (
+	"errors"
+
 	fq "github.com/decentralisedkev/go-jubjub/internal/Fq"
 	"github.com/decentralisedkev/go-jubjub/internal/field"
 )
@@ -36,15 +38,48 @@ func (af *AffinePoint) Equal(b, c *AffinePoint) bool {
 
 // SetExtended sets the Extended Point e, to an Affine Point
 func (af *AffinePoint) SetExtended(e *ExtendedPoint) *AffinePoint {
-	// XXX: before doing inverses, we should have a check for infinity
-	e.z.Inverse(e.z) // z = 1/z
+	// z is never zero for points produced by the complete formulas
+	var zInv fq.FieldQ
+	zInv.Inverse(e.z) // 1/z
 
-	af.u.Mul(e.u, e.z)
-	af.v.Mul(e.v, e.z)
+	af.u.Mul(e.u, zInv)
+	af.v.Mul(e.v, zInv)
 
 	return af
 }
 
+// BatchSetExtended sets out[i] to the affine form of in[i], sharing a
+// single inversion between all points (Montgomery's trick).
+// Panics if the slices have different lengths
+func BatchSetExtended(out []AffinePoint, in []ExtendedPoint) {
+	if len(out) != len(in) {
+		panic("curve: mismatched batch lengths")
+	}
+	if len(in) == 0 {
+		return
+	}
+
+	// prods[i] = z_0 * ... * z_{i-1}
+	prods := make([]fq.FieldQ, len(in))
+	var acc fq.FieldQ
+	acc.SetOne()
+	for i := range in {
+		prods[i] = acc
+		acc.Mul(acc, in[i].z)
+	}
+
+	// acc = 1/(z_0 * ... * z_{n-1}), peeled back one z at a time
+	acc.Inverse(acc)
+	var zInv fq.FieldQ
+	for i := len(in) - 1; i >= 0; i-- {
+		zInv.Mul(acc, prods[i])
+		acc.Mul(acc, in[i].z)
+
+		out[i].u.Mul(in[i].u, zInv)
+		out[i].v.Mul(in[i].v, zInv)
+	}
+}
+
 // IntoBytes converts the af element into its little-endian
 // byte representation
 func (af *AffinePoint) IntoBytes() []byte {
@@ -60,6 +95,59 @@ func (af *AffinePoint) IntoBytes() []byte {
 	return tmp[:]
 }
 
+// UIntoBytes returns the little-endian byte representation
+// of the u-coordinate of af
+func (af *AffinePoint) UIntoBytes() []byte {
+	var u [32]byte
+	af.u.BytesInto(&u)
+	return u[:]
+}
+
+// SetBytes decodes the 32 byte encoding produced by IntoBytes
+// into af. The u-coordinate is recovered from the curve equation:
+// u^2 = (v^2 - 1) / (d.v^2 + 1)
+// Returns an error if v is not canonical, if no such u exists or if
+// the sign bit is set for u = 0 (ZIP 216)
+func (af *AffinePoint) SetBytes(buf [32]byte) (*AffinePoint, error) {
+
+	// Strip the sign of the u-coordinate
+	sign := uint64(buf[31] >> 7)
+	buf[31] &= 0x7f
+
+	if _, err := af.v.SetBytes(&buf); err != nil {
+		return af, err
+	}
+
+	var num, den, one, d fq.FieldQ
+	one.SetOne()
+	d.SetD()
+
+	num.Square(af.v)   // v^2
+	den.Mul(num, d)    // d.v^2
+	den.Add(den, one)  // d.v^2 + 1
+	num.Sub(num, one)  // v^2 - 1
+	den.Inverse(den)   // 1 / (d.v^2 + 1)
+	af.u.Mul(num, den) // (v^2 - 1) / (d.v^2 + 1)
+
+	if _, err := af.u.SqrtVarTime(); err != nil {
+		return af, errors.New("point is not on the curve")
+	}
+
+	// u = 0 has a single encoding
+	if af.u.IsZero() && sign == 1 {
+		return af, errors.New("non-canonical encoding of u = 0")
+	}
+
+	// Pick the root that matches the encoded sign
+	var uBytes [32]byte
+	af.u.BytesInto(&uBytes)
+	if uint64(uBytes[0]&1) != sign {
+		af.u.Neg(af.u)
+	}
+
+	return af, nil
+}
+
 type AffineNielsPoint struct {
 	vPlusU, VminusU, t2d fq.FieldQ
 }
@@ -80,6 +168,37 @@ func (afn *AffineNielsPoint) Zero() *AffineNielsPoint {
 	return afn
 }
 
+// SetExtended sets the AffineNielsPoint from an ExtendedPoint
+func (afn *AffineNielsPoint) SetExtended(e ExtendedPoint) *AffineNielsPoint {
+	var af AffinePoint
+	af.SetExtended(&e)
+	return afn.SetAffine(af)
+}
+
+// Neg negates the AffineNielsPoint
+// returning point (v-u, v+u, -t2d)
+func (afn *AffineNielsPoint) Neg() *AffineNielsPoint {
+	afn.vPlusU, afn.VminusU = afn.VminusU, afn.vPlusU
+	afn.t2d.Neg(afn.t2d)
+	return afn
+}
+
+// ConditionalSelect sets afn to a if c = 1 or b if c = 0, in constant time
+func (afn *AffineNielsPoint) ConditionalSelect(a, b AffineNielsPoint, c uint64) *AffineNielsPoint {
+	afn.vPlusU.CondSel(a.vPlusU.Field, b.vPlusU.Field, c)
+	afn.VminusU.CondSel(a.VminusU.Field, b.VminusU.Field, c)
+	afn.t2d.CondSel(a.t2d.Field, b.t2d.Field, c)
+	return afn
+}
+
+// ConditionalNegate negates afn if c = 1 and leaves it unchanged if c = 0,
+// in constant time
+func (afn *AffineNielsPoint) ConditionalNegate(c uint64) *AffineNielsPoint {
+	neg := *afn
+	neg.Neg()
+	return afn.ConditionalSelect(neg, *afn, c)
+}
+
 // SetAffine sets the AffineNielsPoint from an AffinePoint
 func (afn *AffineNielsPoint) SetAffine(af AffinePoint) *AffineNielsPoint {
 
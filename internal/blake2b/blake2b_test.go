@@ -0,0 +1,56 @@
+package blake2b
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	xblake2b "golang.org/x/crypto/blake2b"
+)
+
+func TestSum512RFC7693(t *testing.T) {
+	// Appendix A of RFC 7693
+	sum := Sum512([]byte("abc"), nil)
+	assert.Equal(t, "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923", hex.EncodeToString(sum[:]))
+}
+
+func TestSum512MatchesXCrypto(t *testing.T) {
+	// Lengths around the block boundaries
+	for _, n := range []int{0, 1, 127, 128, 129, 255, 256, 257, 1000} {
+		data := make([]byte, n)
+		for i := range data {
+			data[i] = byte(i)
+		}
+
+		expected := xblake2b.Sum512(data)
+		assert.Equal(t, expected, Sum512(data, nil), "n = %d", n)
+
+		// Writing in pieces gives the same result
+		h, err := New(Size, nil)
+		assert.Nil(t, err)
+		for i := 0; i < n; i += 7 {
+			end := i + 7
+			if end > n {
+				end = n
+			}
+			h.Write(data[i:end])
+		}
+		assert.Equal(t, expected[:], h.Sum(nil), "n = %d", n)
+	}
+}
+
+func TestPersonalization(t *testing.T) {
+	// Computed with Python's hashlib.blake2b(person=...)
+	sum := Sum512([]byte("abc"), []byte("Zcash_RedJubjubH"))
+	assert.Equal(t, "55af0aaebac9991ee883cf5382069e38c09bf99ca8e00b22730ff84c890961efdb0b384077cd6ef6cf061a8b296f0b0e72f56ba42b99b0aa119673727c951231", hex.EncodeToString(sum[:]))
+
+	data := make([]byte, 200)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	sum = Sum512(data, []byte("Zcash_RedJubjubH"))
+	assert.Equal(t, "c6898263233689be170df510c6d50b9edcd129115b710bff3515424dce5d2934ed32e926cb655c9b7c1d6740390286c33bfd3643845a1f9b4274dfc92de90983", hex.EncodeToString(sum[:]))
+
+	_, err := New(Size, []byte("personalization too long"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,170 @@
+// Package blake2s implements BLAKE2s as defined in RFC 7693, with
+// support for the personalization parameter that Zcash uses for
+// domain separation and that golang.org/x/crypto/blake2s does not expose
+package blake2s
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"math/bits"
+)
+
+const (
+	// BlockSize is the block size of BLAKE2s in bytes
+	BlockSize = 64
+	// Size is the size of a BLAKE2s-256 checksum in bytes
+	Size = 32
+	// PersonalSize is the size of the personalization in bytes
+	PersonalSize = 8
+)
+
+var iv = [8]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a,
+	0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+var sigma = [10][16]byte{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+}
+
+type digest struct {
+	h      [8]uint32
+	t      uint64
+	block  [BlockSize]byte
+	offset int
+	size   int
+
+	init [8]uint32
+}
+
+// New returns a hash.Hash computing the unkeyed BLAKE2s checksum of the
+// given size in bytes, with the given personalization of up to 8 bytes
+func New(size int, personal []byte) (hash.Hash, error) {
+	if size < 1 || size > Size {
+		return nil, errors.New("blake2s: invalid hash size")
+	}
+	if len(personal) > PersonalSize {
+		return nil, errors.New("blake2s: personalization is too long")
+	}
+
+	var params [32]byte
+	params[0] = byte(size)
+	params[2] = 1 // fanout
+	params[3] = 1 // depth
+	copy(params[24:], personal)
+
+	d := &digest{size: size}
+	for i := range d.init {
+		d.init[i] = iv[i] ^ binary.LittleEndian.Uint32(params[4*i:])
+	}
+	d.Reset()
+	return d, nil
+}
+
+// Sum256 returns the BLAKE2s-256 checksum of data with the
+// given personalization of up to 8 bytes
+func Sum256(data, personal []byte) [Size]byte {
+	d, err := New(Size, personal)
+	if err != nil {
+		panic(err)
+	}
+	d.Write(data)
+
+	var sum [Size]byte
+	copy(sum[:], d.Sum(nil))
+	return sum
+}
+
+func (d *digest) Size() int      { return d.size }
+func (d *digest) BlockSize() int { return BlockSize }
+
+func (d *digest) Reset() {
+	d.h = d.init
+	d.t = 0
+	d.offset = 0
+}
+
+func (d *digest) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		// The last block is only compressed in Sum, since it
+		// has to be flagged as final
+		if d.offset == BlockSize {
+			d.t += BlockSize
+			compress(&d.h, &d.block, d.t, false)
+			d.offset = 0
+		}
+
+		c := copy(d.block[d.offset:], p)
+		d.offset += c
+		p = p[c:]
+	}
+	return n, nil
+}
+
+func (d *digest) Sum(b []byte) []byte {
+	h := d.h
+	block := d.block
+	for i := d.offset; i < BlockSize; i++ {
+		block[i] = 0
+	}
+	compress(&h, &block, d.t+uint64(d.offset), true)
+
+	var out [Size]byte
+	for i, v := range h {
+		binary.LittleEndian.PutUint32(out[4*i:], v)
+	}
+	return append(b, out[:d.size]...)
+}
+
+func compress(h *[8]uint32, block *[BlockSize]byte, t uint64, final bool) {
+	var m [16]uint32
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint32(block[4*i:])
+	}
+
+	var v [16]uint32
+	copy(v[:8], h[:])
+	copy(v[8:], iv[:])
+	v[12] ^= uint32(t)
+	v[13] ^= uint32(t >> 32)
+	if final {
+		v[14] = ^v[14]
+	}
+
+	g := func(a, b, c, d int, x, y uint32) {
+		v[a] = v[a] + v[b] + x
+		v[d] = bits.RotateLeft32(v[d]^v[a], -16)
+		v[c] = v[c] + v[d]
+		v[b] = bits.RotateLeft32(v[b]^v[c], -12)
+		v[a] = v[a] + v[b] + y
+		v[d] = bits.RotateLeft32(v[d]^v[a], -8)
+		v[c] = v[c] + v[d]
+		v[b] = bits.RotateLeft32(v[b]^v[c], -7)
+	}
+
+	for _, s := range sigma {
+		g(0, 4, 8, 12, m[s[0]], m[s[1]])
+		g(1, 5, 9, 13, m[s[2]], m[s[3]])
+		g(2, 6, 10, 14, m[s[4]], m[s[5]])
+		g(3, 7, 11, 15, m[s[6]], m[s[7]])
+		g(0, 5, 10, 15, m[s[8]], m[s[9]])
+		g(1, 6, 11, 12, m[s[10]], m[s[11]])
+		g(2, 7, 8, 13, m[s[12]], m[s[13]])
+		g(3, 4, 9, 14, m[s[14]], m[s[15]])
+	}
+
+	for i := range h {
+		h[i] ^= v[i] ^ v[i+8]
+	}
+}
@@ -0,0 +1,50 @@
+package blake2s
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	xblake2s "golang.org/x/crypto/blake2s"
+)
+
+func TestSum256RFC7693(t *testing.T) {
+	// Appendix B of RFC 7693
+	sum := Sum256([]byte("abc"), nil)
+	assert.Equal(t, "508c5e8c327c14e2e1a72ba34eeb452f37458b209ed63a294d999b4c86675982", hex.EncodeToString(sum[:]))
+}
+
+func TestSum256MatchesXCrypto(t *testing.T) {
+	// Lengths around the block boundaries
+	for _, n := range []int{0, 1, 63, 64, 65, 127, 128, 129, 1000} {
+		data := make([]byte, n)
+		for i := range data {
+			data[i] = byte(i)
+		}
+
+		expected := xblake2s.Sum256(data)
+		assert.Equal(t, expected, Sum256(data, nil), "n = %d", n)
+
+		// Writing in pieces gives the same result
+		h, err := New(Size, nil)
+		assert.Nil(t, err)
+		for i := 0; i < n; i += 7 {
+			end := i + 7
+			if end > n {
+				end = n
+			}
+			h.Write(data[i:end])
+		}
+		assert.Equal(t, expected[:], h.Sum(nil), "n = %d", n)
+	}
+}
+
+func TestPersonalization(t *testing.T) {
+	a := Sum256([]byte("abc"), []byte("Zcash_G_"))
+	b := Sum256([]byte("abc"), []byte("Zcash_H_"))
+	assert.NotEqual(t, a, b)
+	assert.NotEqual(t, a, Sum256([]byte("abc"), nil))
+
+	_, err := New(Size, []byte("too long personal"))
+	assert.NotNil(t, err)
+}
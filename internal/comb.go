@@ -0,0 +1,157 @@
+package curve
+
+import (
+	"errors"
+
+	fq "github.com/decentralisedkev/go-jubjub/internal/Fq"
+)
+
+// CombTable holds, for i in [0, 32), the multiples
+// [1, ..., 8] * 16^(2i) * P of a fixed point P.
+// It trades 32 times the memory of an AffineNielsTable for
+// removing all but four doublings from a scalar multiplication
+type CombTable [32]AffineNielsTable
+
+// SetExtended fills the comb table with the multiples of p
+func (t *CombTable) SetExtended(p ExtendedPoint) *CombTable {
+	acc := p
+	for i := range t {
+		t[i].SetExtended(acc)
+
+		// acc = 16^2 * acc
+		for j := 0; j < 8; j++ {
+			acc.Double()
+		}
+	}
+	return t
+}
+
+// MulScalarComb sets e = scalar * P, where table holds the comb of P
+// and scalar is the canonical little endian encoding of a scalar.
+//
+// Writing the scalar as sum(e[i] * 16^i), the odd digits are added first,
+// the sum is multiplied by 16 and then the even digits are added:
+// scalar * P = 16 * sum(e[2i+1] * 16^(2i) * P) + sum(e[2i] * 16^(2i) * P)
+func (e *ExtendedPoint) MulScalarComb(table *CombTable, scalar [32]byte) *ExtendedPoint {
+
+	digits := radix16(scalar)
+
+	var res ExtendedPoint
+	res.SetZero()
+
+	var afn AffineNielsPoint
+	for i := 1; i < 64; i += 2 {
+		table[i/2].Select(&afn, digits[i])
+		res.AddAffineNiels(&afn)
+	}
+
+	res.Double()
+	res.Double()
+	res.Double()
+	res.Double()
+
+	for i := 0; i < 64; i += 2 {
+		table[i/2].Select(&afn, digits[i])
+		res.AddAffineNiels(&afn)
+	}
+
+	*e = res
+
+	return e
+}
+
+// CombTableSize is the length of the encoding of a CombTable
+const CombTableSize = 32 * 8 * 64
+
+// half = 1/2, used to recover (u, v) from (v+u, v-u)
+var half = func() fq.FieldQ {
+	var two, h fq.FieldQ
+	two.FromU64(2)
+	h.Inverse(two)
+	return h
+}()
+
+// IntoBytes encodes the table as the canonical little endian affine
+// coordinates (u, v) of every entry
+func (t *CombTable) IntoBytes() []byte {
+	res := make([]byte, 0, CombTableSize)
+
+	var u, v fq.FieldQ
+	var buf [32]byte
+	for i := range t {
+		for j := range t[i] {
+			afn := &t[i][j]
+			u.Sub(afn.vPlusU, afn.VminusU)
+			u.Mul(u, half)
+			v.Add(afn.vPlusU, afn.VminusU)
+			v.Mul(v, half)
+
+			u.BytesInto(&buf)
+			res = append(res, buf[:]...)
+			v.BytesInto(&buf)
+			res = append(res, buf[:]...)
+		}
+	}
+	return res
+}
+
+// SetBytes decodes a table encoded by IntoBytes.
+// Returns an error if a coordinate is not canonical, if an entry is not
+// on the curve, or if the entries are not the multiples of a single point
+func (t *CombTable) SetBytes(b []byte) (*CombTable, error) {
+	if len(b) != CombTableSize {
+		return t, errors.New("invalid comb table length")
+	}
+
+	var entries [32][8]ExtendedPoint
+	var res CombTable
+	var buf [32]byte
+	for i := range res {
+		for j := range res[i] {
+			var af AffinePoint
+
+			copy(buf[:], b[:32])
+			if _, err := af.u.SetBytes(&buf); err != nil {
+				return t, err
+			}
+			copy(buf[:], b[32:64])
+			if _, err := af.v.SetBytes(&buf); err != nil {
+				return t, err
+			}
+			b = b[64:]
+
+			if !af.isOnCurveVarTime() {
+				return t, errors.New("comb table entry is not on the curve")
+			}
+
+			res[i][j].SetAffine(af)
+			entries[i][j].SetAffine(af)
+		}
+	}
+
+	// Check that entries[i][j] = (j+1) * 16^(2i) * entries[0][0]
+	var acc, next ExtendedPoint
+	next = entries[0][0]
+	for i := range entries {
+		if !acc.Equal(next, entries[i][0]) {
+			return t, errors.New("comb table entries are inconsistent")
+		}
+
+		acc = entries[i][0]
+		for j := 1; j < 8; j++ {
+			acc.Add(acc, entries[i][0])
+			if !acc.Equal(acc, entries[i][j]) {
+				return t, errors.New("comb table entries are inconsistent")
+			}
+		}
+
+		next = entries[i][0]
+		for j := 0; j < 8; j++ {
+			next.Double()
+		}
+	}
+
+	*t = res
+
+	return t, nil
+}
@@ -0,0 +1,131 @@
+package curve
+
+import (
+	fq "github.com/decentralisedkev/go-jubjub/internal/Fq"
+	"github.com/decentralisedkev/go-jubjub/internal/field"
+)
+
+// Jubjub is birationally equivalent to the Montgomery curve
+// K.t^2 = s^3 + J.s^2 + s with J = 2(a + d)/(a - d) and K = 4/(a - d),
+// where a = -1. Elligator 2 maps to the Montgomery curve, which is then
+// mapped to the twisted Edwards curve with u = s/t, v = (s - 1)/(s + 1).
+// See RFC 9380, sections 6.7.1 and 6.8.2
+var (
+	// ellJK = J/K = (d - 1)/2
+	ellJK fq.FieldQ
+	// ellInvK2 = 1/K^2 = ((1 + d)/4)^2
+	ellInvK2 fq.FieldQ
+	// ellK = K = 4/(-1 - d)
+	ellK fq.FieldQ
+	// ellZ = 5, the first non-square in the order 1, -1, 2, -2, 3, ...
+	ellZ fq.FieldQ
+)
+
+func init() {
+	var one, two, four, d, tmp fq.FieldQ
+	one.SetOne()
+	two.FromU64(2)
+	four.FromU64(4)
+	d.SetD()
+
+	tmp.Inverse(two)
+	ellJK.Sub(d, one)
+	ellJK.Mul(ellJK, tmp)
+
+	tmp.Inverse(four)
+	ellInvK2.Add(d, one)
+	ellInvK2.Mul(ellInvK2, tmp)
+	ellInvK2.Square(ellInvK2)
+
+	tmp.Add(d, one)
+	tmp.Neg(tmp)
+	ellK.Inverse(tmp)
+	ellK.Mul(ellK, four)
+
+	ellZ.FromU64(5)
+}
+
+// MapToCurve sets e to the image of u under Elligator 2, as specified
+// by map_to_curve_elligator2 in RFC 9380. The resulting point is not
+// necessarily in the prime order subgroup. Runs in constant time
+func (e *ExtendedPoint) MapToCurve(u fq.FieldQ) *ExtendedPoint {
+	var one, zero, tv, inv, x1, x2, gx1, gx2, y1, y2, x, y, negY fq.FieldQ
+	one.SetOne()
+
+	// x1 = -(J/K) * inv0(1 + Z * u^2)
+	tv.Square(u)
+	tv.Mul(tv, ellZ)
+	tv.Add(tv, one)
+	isZero := field.ConstantTimeEq(tv.Field, zero.Field)
+	tv.CondSel(one.Field, tv.Field, isZero)
+	inv.Inverse(tv)
+	inv.CondSel(zero.Field, inv.Field, isZero)
+
+	x1.Neg(ellJK)
+	x1.Mul(x1, inv)
+
+	// if x1 == 0, set x1 = -(J/K). This only happens when inv0 returned 0
+	negJK := fq.FieldQ{}
+	negJK.Neg(ellJK)
+	x1.CondSel(negJK.Field, x1.Field, isZero)
+
+	// x2 = -x1 - J/K
+	x2.Sub(negJK, x1)
+
+	montgomeryRHS(&gx1, x1)
+	montgomeryRHS(&gx2, x2)
+
+	isSquare := y1.Sqrt(gx1)
+	y2.Sqrt(gx2)
+
+	// Take x1 with sgn0(y) = 1 if gx1 is square, otherwise x2 with sgn0(y) = 0
+	x.CondSel(x1.Field, x2.Field, isSquare)
+	y.CondSel(y1.Field, y2.Field, isSquare)
+	negY.Neg(y)
+	y.CondSel(negY.Field, y.Field, y.Sgn0()^isSquare)
+
+	var s, t fq.FieldQ
+	s.Mul(x, ellK)
+	t.Mul(y, ellK)
+
+	e.setMontgomery(s, t)
+	return e
+}
+
+// montgomeryRHS sets g to x^3 + (J/K).x^2 + x/K^2
+func montgomeryRHS(g *fq.FieldQ, x fq.FieldQ) {
+	g.Add(x, ellJK)
+	g.Mul(*g, x)
+	g.Add(*g, ellInvK2)
+	g.Mul(*g, x)
+}
+
+// setMontgomery sets e to the twisted Edwards image of the Montgomery
+// point (s, t), using u = s/t and v = (s - 1)/(s + 1). The exceptional
+// points with t = 0 or s = -1 map to the identity
+func (e *ExtendedPoint) setMontgomery(s, t fq.FieldQ) *ExtendedPoint {
+	var one, zero, sPlusOne, sMinusOne, u, v, z fq.FieldQ
+	one.SetOne()
+
+	sPlusOne.Add(s, one)
+	sMinusOne.Sub(s, one)
+
+	// (u : v : z) = (s.(s + 1) : (s - 1).t : t.(s + 1))
+	u.Mul(s, sPlusOne)
+	v.Mul(sMinusOne, t)
+	z.Mul(t, sPlusOne)
+
+	// z = 0 exactly for the exceptional points
+	exceptional := field.ConstantTimeEq(z.Field, zero.Field)
+
+	// Scale by z so that t1 * t2 = uv/z holds without an inversion
+	e.u.Mul(u, z)
+	e.v.Mul(v, z)
+	e.z.Square(z)
+	e.t1.Set(u)
+	e.t2.Set(v)
+
+	var id ExtendedPoint
+	id.Identity()
+	return e.ConditionalSelect(id, *e, exceptional)
+}
@@ -0,0 +1,53 @@
+package curve
+
+import (
+	"encoding/hex"
+	"testing"
+
+	fq "github.com/decentralisedkev/go-jubjub/internal/Fq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapToCurve(t *testing.T) {
+	var u fq.FieldQ
+	var e ExtendedPoint
+
+	// Vectors computed with an independent implementation of
+	// map_to_curve_elligator2 and the rational map to Jubjub
+	u.SetZero()
+	e.MapToCurve(u)
+	assert.True(t, e.IsIdentity()) // u = 0 hits the exceptional point t = 0
+
+	u.FromU64(1)
+	e.MapToCurve(u)
+	assert.Equal(t, "9d4bd08b3912d9ea122d7a38b02788ba9520e4593f6ace5294102118026e11bb", hex.EncodeToString(e.IntoBytes()))
+
+	// The map only depends on u^2
+	u.Neg(u)
+	e.MapToCurve(u)
+	assert.Equal(t, "9d4bd08b3912d9ea122d7a38b02788ba9520e4593f6ace5294102118026e11bb", hex.EncodeToString(e.IntoBytes()))
+
+	u.FromU64(5)
+	e.MapToCurve(u)
+	assert.Equal(t, "5f3e69fb5749f35a36ca9cbb43763cd8aa8205f6f013ad1b6955a7ab66c5ea63", hex.EncodeToString(e.IntoBytes()))
+
+	for i := 0; i < 100; i++ {
+		u.Rand()
+		e.MapToCurve(u)
+		assert.True(t, e.isOnCurveVarTime())
+	}
+}
+
+func TestSetMontgomeryExceptional(t *testing.T) {
+	var s, tt, one fq.FieldQ
+	var e ExtendedPoint
+	one.SetOne()
+
+	s.Rand()
+	tt.SetZero()
+	assert.True(t, e.setMontgomery(s, tt).IsIdentity())
+
+	s.Neg(one)
+	tt.Rand()
+	assert.True(t, e.setMontgomery(s, tt).IsIdentity())
+}
@@ -0,0 +1,168 @@
+// Package expand implements expand_message_xmd and expand_message_xof
+// from RFC 9380, section 5.3, which turn a message and a domain
+// separation tag into a uniformly random byte string
+package expand
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"hash"
+	"io"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/blake2s"
+	"golang.org/x/crypto/sha3"
+)
+
+// maxDSTLen is the longest domain separation tag that is used as is.
+// Longer tags are hashed first, see RFC 9380 section 5.3.3
+const maxDSTLen = 255
+
+var oversizeDSTPrefix = []byte("H2C-OVERSIZE-DST-")
+
+// Expander expands msg and dst into lenInBytes uniformly random bytes
+type Expander interface {
+	Expand(msg, dst []byte, lenInBytes int) ([]byte, error)
+}
+
+// XOF is an extendable output function
+type XOF interface {
+	io.Writer
+	io.Reader
+}
+
+var (
+	// SHA256 is expand_message_xmd with SHA-256
+	SHA256 Expander = NewXMD(sha256.New)
+	// SHA512 is expand_message_xmd with SHA-512
+	SHA512 Expander = NewXMD(sha512.New)
+	// SHAKE128 is expand_message_xof with SHAKE128
+	SHAKE128 Expander = NewXOF(func(int) (XOF, error) { return sha3.NewShake128(), nil }, 128)
+	// SHAKE256 is expand_message_xof with SHAKE256
+	SHAKE256 Expander = NewXOF(func(int) (XOF, error) { return sha3.NewShake256(), nil }, 256)
+	// BLAKE2s is expand_message_xof with BLAKE2Xs
+	BLAKE2s Expander = NewXOF(newBlake2sXOF, 128)
+	// BLAKE2b is expand_message_xof with BLAKE2Xb
+	BLAKE2b Expander = NewXOF(newBlake2bXOF, 256)
+)
+
+type xmd struct {
+	h func() hash.Hash
+}
+
+// NewXMD returns expand_message_xmd over the hash function h.
+// h must be a Merkle-Damgard hash such as SHA-256 or SHA-512
+func NewXMD(h func() hash.Hash) Expander {
+	return &xmd{h}
+}
+
+// Expand implements the Expander interface
+func (x *xmd) Expand(msg, dst []byte, lenInBytes int) ([]byte, error) {
+	h := x.h()
+	bInBytes := h.Size()
+	rInBytes := h.BlockSize()
+
+	ell := (lenInBytes + bInBytes - 1) / bInBytes
+	if ell > 255 || lenInBytes > 65535 || lenInBytes < 0 {
+		return nil, errors.New("expand: requested output is too long")
+	}
+
+	if len(dst) > maxDSTLen {
+		h.Write(oversizeDSTPrefix)
+		h.Write(dst)
+		dst = h.Sum(nil)
+		h.Reset()
+	}
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+
+	// b_0 = H(Z_pad || msg || l_i_b_str || I2OSP(0, 1) || DST_prime)
+	h.Write(make([]byte, rInBytes))
+	h.Write(msg)
+	h.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes), 0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	// b_1 = H(b_0 || I2OSP(1, 1) || DST_prime)
+	h.Reset()
+	h.Write(b0)
+	h.Write([]byte{1})
+	h.Write(dstPrime)
+	bi := h.Sum(nil)
+
+	out := make([]byte, 0, ell*bInBytes)
+	out = append(out, bi...)
+
+	// b_i = H(strxor(b_0, b_(i - 1)) || I2OSP(i, 1) || DST_prime)
+	tmp := make([]byte, bInBytes)
+	for i := 2; i <= ell; i++ {
+		for j := range tmp {
+			tmp[j] = b0[j] ^ bi[j]
+		}
+		h.Reset()
+		h.Write(tmp)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(bi[:0])
+		out = append(out, bi...)
+	}
+
+	return out[:lenInBytes], nil
+}
+
+type xof struct {
+	h func(size int) (XOF, error)
+	k int
+}
+
+// NewXOF returns expand_message_xof over the extendable output
+// function h, which is called with the number of bytes that will be
+// read from it. k is the target security level in bits
+func NewXOF(h func(size int) (XOF, error), k int) Expander {
+	return &xof{h, k}
+}
+
+// Expand implements the Expander interface
+func (x *xof) Expand(msg, dst []byte, lenInBytes int) ([]byte, error) {
+	if lenInBytes > 65535 || lenInBytes < 0 {
+		return nil, errors.New("expand: requested output is too long")
+	}
+
+	if len(dst) > maxDSTLen {
+		size := (2*x.k + 7) / 8
+		h, err := x.h(size)
+		if err != nil {
+			return nil, err
+		}
+		h.Write(oversizeDSTPrefix)
+		h.Write(dst)
+		dst = make([]byte, size)
+		if _, err := io.ReadFull(h, dst); err != nil {
+			return nil, err
+		}
+	}
+
+	// uniform_bytes = H(msg || I2OSP(len_in_bytes, 2) || DST_prime, len_in_bytes)
+	h, err := x.h(lenInBytes)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(msg)
+	h.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes)})
+	h.Write(dst)
+	h.Write([]byte{byte(len(dst))})
+
+	out := make([]byte, lenInBytes)
+	if _, err := io.ReadFull(h, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func newBlake2sXOF(size int) (XOF, error) {
+	return blake2s.NewXOF(uint16(size), nil)
+}
+
+func newBlake2bXOF(size int) (XOF, error) {
+	return blake2b.NewXOF(uint32(size), nil)
+}
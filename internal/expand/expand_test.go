@@ -0,0 +1,76 @@
+package expand
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test vectors from RFC 9380, appendix K. The long DST vectors use a
+// 256 byte tag and were computed with an independent implementation
+func TestExpandVectors(t *testing.T) {
+	longDST := func(prefix string) []byte {
+		return []byte(prefix + strings.Repeat("1", 256))
+	}
+
+	tests := []struct {
+		name     string
+		e        Expander
+		dst      []byte
+		msg      string
+		len      int
+		expected string
+	}{
+		{"sha256 empty", SHA256, []byte("QUUX-V01-CS02-with-expander-SHA256-128"), "", 0x20,
+			"68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235"},
+		{"sha256 abc", SHA256, []byte("QUUX-V01-CS02-with-expander-SHA256-128"), "abc", 0x20,
+			"d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615"},
+		{"sha256 long output", SHA256, []byte("QUUX-V01-CS02-with-expander-SHA256-128"), "abcdef0123456789", 0x80,
+			"ef904a29bffc4cf9ee82832451c946ac3c8f8058ae97d8d629831a74c6572bd9ebd0df635cd1f208e2038e760c4994984ce73f0d55ea9f22af83ba4734569d4bc95e18350f740c07eef653cbb9f87910d833751825f0ebefa1abe5420bb52be14cf489b37fe1a72f7de2d10be453b2c9d9eb20c7e3f6edc5a60629178d9478df"},
+		{"sha256 long dst", SHA256, longDST("QUUX-V01-CS02-with-expander-SHA256-128-long-DST-"), "abc", 0x20,
+			"5c7e9de83dce79df35cebe686af6ea233fa8db609c8af386618a3470634bbd4b"},
+		{"sha512 abc", SHA512, []byte("QUUX-V01-CS02-with-expander-SHA512-256"), "abc", 0x20,
+			"0da749f12fbe5483eb066a5f595055679b976e93abe9be6f0f6318bce7aca8dc"},
+		{"shake128 empty", SHAKE128, []byte("QUUX-V01-CS02-with-expander-SHAKE128"), "", 0x20,
+			"86518c9cd86581486e9485aa74ab35ba150d1c75c88e26b7043e44e2acd735a2"},
+		{"shake128 long output", SHAKE128, []byte("QUUX-V01-CS02-with-expander-SHAKE128"), "abc", 0x80,
+			"c952f0c8e529ca8824acc6a4cab0e782fc3648c563ddb00da7399f2ae35654f4860ec671db2356ba7baa55a34a9d7f79197b60ddae6e64768a37d699a78323496db3878c8d64d909d0f8a7de4927dcab0d3dbbc26cb20a49eceb0530b431cdf47bc8c0fa3e0d88f53b318b6739fbed7d7634974f1b5c386d6230c76260d5337a"},
+		{"shake128 long dst", SHAKE128, longDST("QUUX-V01-CS02-with-expander-SHAKE128-long-DST-"), "abc", 0x20,
+			"baaa4cc3f7146be2e9f43aa790684f47cb6912d9318ed9fd085ede72ae75185a"},
+	}
+
+	for _, tt := range tests {
+		out, err := tt.e.Expand([]byte(tt.msg), tt.dst, tt.len)
+		assert.Nil(t, err, tt.name)
+		assert.Equal(t, tt.expected, hex.EncodeToString(out), tt.name)
+	}
+}
+
+func TestExpandDomainSeparation(t *testing.T) {
+	for _, e := range []Expander{SHA256, SHA512, SHAKE128, SHAKE256, BLAKE2s, BLAKE2b} {
+		a, err := e.Expand([]byte("msg"), []byte("DST-A"), 96)
+		assert.Nil(t, err)
+		b, err := e.Expand([]byte("msg"), []byte("DST-B"), 96)
+		assert.Nil(t, err)
+		c, err := e.Expand([]byte("msg"), []byte("DST-A"), 96)
+		assert.Nil(t, err)
+
+		assert.Equal(t, 96, len(a))
+		assert.False(t, bytes.Equal(a, b))
+		assert.Equal(t, a, c)
+	}
+}
+
+func TestExpandTooLong(t *testing.T) {
+	_, err := SHA256.Expand(nil, []byte("DST"), 255*32+1)
+	assert.NotNil(t, err)
+
+	_, err = SHAKE128.Expand(nil, []byte("DST"), 65536)
+	assert.NotNil(t, err)
+
+	_, err = SHA512.Expand(nil, []byte("DST"), 255*64)
+	assert.Nil(t, err)
+}
@@ -1,8 +1,6 @@
 package curve
 
 import (
-	"fmt"
-
 	fq "github.com/decentralisedkev/go-jubjub/internal/Fq"
 	"github.com/decentralisedkev/go-jubjub/internal/field"
 )
@@ -17,9 +15,7 @@ type ExtendedPoint struct {
 // => (a.u * b.z) = (b.u * a.z) & (a.v * b.z) = (b.v * a.z)
 func (e *ExtendedPoint) Equal(a, b ExtendedPoint) bool {
 
-	// XXX: Optimise later on, one is to remove so many mallocs
-
-	var c1, c2, c3, c4 *fq.FieldQ
+	var c1, c2, c3, c4 fq.FieldQ
 
 	c1.Mul(a.u, b.z)
 	c2.Mul(b.u, a.z)
@@ -91,13 +87,68 @@ func (e *ExtendedPoint) MulCof() *ExtendedPoint {
 	return e
 }
 
-// Identity returns the identity point
+// order is the little endian encoding of r, the order
+// of the prime order subgroup
+var order = [32]byte{
+	0xb7, 0x2c, 0xf7, 0xd6, 0x5e, 0x0e, 0x97, 0xd0,
+	0x82, 0x10, 0xc8, 0xcc, 0x93, 0x20, 0x68, 0xa6,
+	0x00, 0x3b, 0x34, 0x01, 0x01, 0x3b, 0x67, 0x06,
+	0xa9, 0xaf, 0x33, 0x65, 0xea, 0xb4, 0x7d, 0x0e,
+}
+
+// IsSmallOrder returns true if e is in the torsion subgroup
+// of order 8, i.e. 8 * e is the identity
+func (e *ExtendedPoint) IsSmallOrder() bool {
+	tmp := *e
+	return tmp.MulCof().IsIdentity()
+}
+
+// IsTorsionFree returns true if e is in the prime order
+// subgroup, i.e. r * e is the identity
+func (e *ExtendedPoint) IsTorsionFree() bool {
+	var tmp ExtendedPoint
+	return tmp.MulScalar(*e, order).IsIdentity()
+}
+
+// Identity sets e to the identity point (0, 1)
 func (e *ExtendedPoint) Identity() *ExtendedPoint {
-	e.u.Neg(e.u)
-	e.t1.Neg(e.t1)
+	return e.SetZero()
+}
+
+// IsIdentity returns true if e is the identity point
+// => u = 0 & v = z
+func (e *ExtendedPoint) IsIdentity() bool {
+	return e.u.IsZero() && field.Equal(e.v.Field, e.z.Field)
+}
+
+// ConditionalSelect sets e to a if c = 1 or b if c = 0, in constant time
+func (e *ExtendedPoint) ConditionalSelect(a, b ExtendedPoint, c uint64) *ExtendedPoint {
+	e.u.CondSel(a.u.Field, b.u.Field, c)
+	e.v.CondSel(a.v.Field, b.v.Field, c)
+	e.z.CondSel(a.z.Field, b.z.Field, c)
+	e.t1.CondSel(a.t1.Field, b.t1.Field, c)
+	e.t2.CondSel(a.t2.Field, b.t2.Field, c)
 	return e
 }
 
+// Add sets e = a + b
+func (e *ExtendedPoint) Add(a, b ExtendedPoint) *ExtendedPoint {
+	var bn ExtendedNielsPoint
+	bn.SetExtended(b)
+
+	*e = a
+	return e.AddExtendedNiels(&bn)
+}
+
+// Sub sets e = a - b
+func (e *ExtendedPoint) Sub(a, b ExtendedPoint) *ExtendedPoint {
+	var bn ExtendedNielsPoint
+	bn.SetExtended(b).Neg()
+
+	*e = a
+	return e.AddExtendedNiels(&bn)
+}
+
 // SetAffine sets the Affine Point af, to an Extended Point
 func (e *ExtendedPoint) SetAffine(af AffinePoint) *ExtendedPoint {
 
@@ -112,18 +163,23 @@ func (e *ExtendedPoint) SetAffine(af AffinePoint) *ExtendedPoint {
 	return e
 }
 
-// SetCompleted sets the completedPoint c to ExtendedPoint e
-func (e *ExtendedPoint) SetCompleted(c CompletedPoint) *ExtendedPoint {
-	e.u.Mul(c.u, c.t)
-	e.v.Mul(c.v, c.z)
-	e.z.Mul(c.t, c.z)
+// SetBytes decodes a 32 byte point encoding into e
+func (e *ExtendedPoint) SetBytes(buf [32]byte) (*ExtendedPoint, error) {
+	var af AffinePoint
+	if _, err := af.SetBytes(buf); err != nil {
+		return e, err
+	}
+	return e.SetAffine(af), nil
+}
 
-	e.t1.Set(c.u)
-	e.t2.Set(c.v)
-	return e
+// IntoBytes converts e into its 32 byte encoding
+func (e *ExtendedPoint) IntoBytes() []byte {
+	var af AffinePoint
+	return af.SetExtended(e).IntoBytes()
 }
 
-func (e *ExtendedPoint) Bytes() *ExtendedPoint {
+// SetCompleted sets the completedPoint c to ExtendedPoint e
+func (e *ExtendedPoint) SetCompleted(c CompletedPoint) *ExtendedPoint {
 	e.u.Mul(c.u, c.t)
 	e.v.Mul(c.v, c.z)
 	e.z.Mul(c.t, c.z)
@@ -145,9 +201,6 @@ func (e *ExtendedPoint) isOnCurveVarTime() bool {
 	s.Mul(s, e.z)
 	t12.Mul(e.t1, e.t2)
 
-	fmt.Println("Not Zero", !e.z.IsZero())
-	fmt.Println("affine on curve", af.isOnCurveVarTime())
-
 	return !e.z.IsZero() && af.isOnCurveVarTime() && field.Equal(t12.Field, s.Field)
 }
 
@@ -159,7 +212,7 @@ v^2 - 1 = u^2 (d.v^2 + 1)
 (v^2 - 1) / (d.v^2 + 1) = u^2
 u^2 = (v^2 - 1) / (d.v^2 + 1)
 */
-// Will be replaced with Elligator2: https://elligator.cr.yp.to/elligator-20130828.pdf
+// MapToCurve should be preferred, as it implements Elligator 2 in constant time.
 // Uses a variation of the try-and-increment method, therefore it is var-time, see 1.1: https://eprint.iacr.org/2009/226.pdf
 func (e *ExtendedPoint) FromBytes(byt [64]byte) *ExtendedPoint {
 
@@ -206,34 +259,29 @@ func (e *ExtendedPoint) FromBytes(byt [64]byte) *ExtendedPoint {
 	return e
 }
 
-// MulScalar TODO
-// Take in a generic Field element
-// XXX: Optimise to use ExtendedNiels
+// MulScalar sets e = scalar * point, where scalar is the canonical
+// little endian encoding of a scalar.
+// Uses a fixed window of signed radix-16 digits over a table of
+// ExtendedNielsPoints, so the sequence of operations does not depend on the scalar
 func (e *ExtendedPoint) MulScalar(point ExtendedPoint, scalar [32]byte) *ExtendedPoint {
 
-	var res ExtendedPoint
-	res.SetZero()
-
-	var cp CompletedPoint
-
-	fmt.Println("Scalar", scalar)
+	var table nielsTable
+	table.SetExtended(point)
 
-	for _, byt := range scalar {
-		for i := byte(8); i > 0; i-- { // Iterate over bit XXX: We need to double check the endianess
+	digits := radix16(scalar)
 
-			bit := byt >> (i - 1) & 1
-
-			// Double
-			res.Double()
-
-			if bit == 1 {
+	var res ExtendedPoint
+	res.SetZero()
 
-				// Add
-				cp.AddExtended(res, point)
-				res.SetCompleted(cp)
+	var en ExtendedNielsPoint
+	for i := 63; i >= 0; i-- {
+		res.Double()
+		res.Double()
+		res.Double()
+		res.Double()
 
-			}
-		}
+		table.Select(&en, digits[i])
+		res.AddExtendedNiels(&en)
 	}
 
 	*e = res
@@ -255,7 +303,6 @@ func (en *ExtendedNielsPoint) Identity() *ExtendedNielsPoint {
 }
 
 // SetExtended Sets ExtendedNielsPoint from an ExtendedPoint
-// Check
 func (en *ExtendedNielsPoint) SetExtended(e ExtendedPoint) *ExtendedNielsPoint {
 
 	var d2 fq.FieldQ
@@ -264,7 +311,7 @@ func (en *ExtendedNielsPoint) SetExtended(e ExtendedPoint) *ExtendedNielsPoint {
 	en.VminusU.Sub(e.v, e.u)
 	en.vPlusU.Add(e.v, e.u)
 	en.z.Set(e.z)
-	en.t2d.Mul(e.u, e.v)
+	en.t2d.Mul(e.t1, e.t2)
 	en.t2d.Mul(en.t2d, d2)
 	return en
 }
@@ -323,9 +370,29 @@ func (p *ExtendedPoint) AddAffineNiels(q *AffineNielsPoint) *ExtendedPoint {
 	return p
 }
 
-// TODO
+// Neg negates the ExtendedNielsPoint
+// returning point (v-u, v+u, z, -t2d)
 func (en *ExtendedNielsPoint) Neg() *ExtendedNielsPoint {
-	return nil
+	en.vPlusU, en.VminusU = en.VminusU, en.vPlusU
+	en.t2d.Neg(en.t2d)
+	return en
+}
+
+// ConditionalSelect sets en to a if c = 1 or b if c = 0, in constant time
+func (en *ExtendedNielsPoint) ConditionalSelect(a, b ExtendedNielsPoint, c uint64) *ExtendedNielsPoint {
+	en.vPlusU.CondSel(a.vPlusU.Field, b.vPlusU.Field, c)
+	en.VminusU.CondSel(a.VminusU.Field, b.VminusU.Field, c)
+	en.z.CondSel(a.z.Field, b.z.Field, c)
+	en.t2d.CondSel(a.t2d.Field, b.t2d.Field, c)
+	return en
+}
+
+// ConditionalNegate negates en if c = 1 and leaves it unchanged if c = 0,
+// in constant time
+func (en *ExtendedNielsPoint) ConditionalNegate(c uint64) *ExtendedNielsPoint {
+	neg := *en
+	neg.Neg()
+	return en.ConditionalSelect(neg, *en, c)
 }
 
 // Zero sets the ExtendedNielsPoint to Zero
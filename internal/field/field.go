@@ -103,10 +103,41 @@ func (f *Field) FromBytes(byt [64]byte, INV uint64, modulus, R2, R3 Field) *Fiel
 	return f
 }
 
+// SetCanonicalBytes takes a 32 byte little endian array and sets f
+// to its Montgomery form. Returns false if the value is not strictly
+// less than the modulus
+func (f *Field) SetCanonicalBytes(buf *[32]byte, INV uint64, modulus, R2 Field) bool {
+
+	var tmp Field
+	tmp[0] = binary.LittleEndian.Uint64(buf[0:8])
+	tmp[1] = binary.LittleEndian.Uint64(buf[8:16])
+	tmp[2] = binary.LittleEndian.Uint64(buf[16:24])
+	tmp[3] = binary.LittleEndian.Uint64(buf[24:32])
+
+	// Try to subtract the modulus, if the value is canonical
+	// this underflows and borrow = 0xfff...fff
+	_, borrow := futil.Sbb(tmp[0], modulus[0], 0)
+	_, borrow = futil.Sbb(tmp[1], modulus[1], borrow)
+	_, borrow = futil.Sbb(tmp[2], modulus[2], borrow)
+	_, borrow = futil.Sbb(tmp[3], modulus[3], borrow)
+
+	// Convert to Montgomery form
+	f.Mul(tmp, R2, INV, modulus)
+
+	return borrow != 0
+}
+
 func Equal(a, b Field) bool {
 	return a[0] == b[0] && a[1] == b[1] && a[2] == b[2] && a[3] == b[3]
 }
 
+// ConstantTimeEq returns 1 if a and b are equal and 0 otherwise
+// without branching on their values
+func ConstantTimeEq(a, b Field) uint64 {
+	x := (a[0] ^ b[0]) | (a[1] ^ b[1]) | (a[2] ^ b[2]) | (a[3] ^ b[3])
+	return 1 ^ ((x | -x) >> 63)
+}
+
 // Cmp compares a and b
 // if a > b return 1
 // if a==b return 0
@@ -168,9 +199,18 @@ func (f *Field) Double(modulus Field) *Field {
 	return f.Add(*f, *f, modulus)
 }
 
+// Mul sets f = lhs * rhs in Montgomery form. The result is only correct
+// if lhs * rhs < modulus * 2^256, which holds when both are reduced
 func (f *Field) Mul(lhs, rhs Field, INV uint64, modulus Field) *Field {
-	// XXX: Optimise later
+	if hasADX {
+		mulADX(f, &lhs, &rhs, INV, &modulus)
+		return f
+	}
+	return f.mulGeneric(lhs, rhs, INV, modulus)
+}
 
+// mulGeneric is the portable implementation of Mul
+func (f *Field) mulGeneric(lhs, rhs Field, INV uint64, modulus Field) *Field {
 	r0, carry := futil.Mac(0, lhs[0], rhs[0], 0)
 	r1, carry := futil.Mac(0, lhs[0], rhs[1], carry)
 	r2, carry := futil.Mac(0, lhs[0], rhs[2], carry)
@@ -196,8 +236,18 @@ func (f *Field) Mul(lhs, rhs Field, INV uint64, modulus Field) *Field {
 	return f
 }
 
+// Square sets f = a * a in Montgomery form. The result is only correct
+// if a * a < modulus * 2^256, which holds when a is reduced
 func (f *Field) Square(a Field, INV uint64, modulus Field) *Field {
+	if hasADX {
+		squareADX(f, &a, INV, &modulus)
+		return f
+	}
+	return f.squareGeneric(a, INV, modulus)
+}
 
+// squareGeneric is the portable implementation of Square
+func (f *Field) squareGeneric(a Field, INV uint64, modulus Field) *Field {
 	r1, carry := futil.Mac(0, a[0], a[1], 0)
 	r2, carry := futil.Mac(0, a[0], a[2], carry)
 	r3, r4 := futil.Mac(0, a[0], a[3], carry)
@@ -241,15 +291,11 @@ func (f *Field) Neg(a, modulus Field) *Field {
 	d2, borrow := futil.Sbb(modulus[2], a[2], borrow)
 	d3, _ := futil.Sbb(modulus[3], a[3], borrow)
 
-	msk := a[0]|a[1]|a[2]|a[3] == 0
-
-	var mask uint64
-	if !msk {
-		mask-- // uint64 max
-	}
-
 	// `tmp` could be `MODULUS` if `self` was zero. Create a mask that is
 	// zero if `self` was zero, and `u64::max_value()` if self was nonzero.
+	// Computed without branching so that Neg is constant time.
+	nz := a[0] | a[1] | a[2] | a[3]
+	mask := -((nz | -nz) >> 63)
 
 	f[0] = d0 & mask
 	f[1] = d1 & mask
@@ -316,6 +362,9 @@ func (f *Field) BytesInto(buf *[32]byte, modulus Field, INV uint64) *Field {
 	return f
 }
 
+// montRed returns T / 2^256 mod modulus for the 512 bit T = r7..r0.
+// T must be less than modulus * 2^256, as the final carry is dropped
+// and the modulus is subtracted at most once
 func montRed(r0, r1, r2, r3, r4, r5, r6, r7, INV uint64, modulus Field) *Field {
 
 	k := r0 * INV
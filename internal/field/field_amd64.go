@@ -0,0 +1,24 @@
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+package field
+
+import "golang.org/x/sys/cpu"
+
+// hasADX reports whether the MULX, ADCX and ADOX instructions used by
+// mulADX and squareADX are available
+var hasADX = cpu.X86.HasBMI2 && cpu.X86.HasADX
+
+// mulADX sets res = a * b / 2^256 mod modulus for
+// a * b < modulus * 2^256. It matches mulGeneric bit for bit, even
+// outside that range
+//
+//go:noescape
+func mulADX(res, a, b *Field, inv uint64, modulus *Field)
+
+// squareADX sets res = a * a / 2^256 mod modulus for
+// a * a < modulus * 2^256. It matches squareGeneric bit for bit, even
+// outside that range
+//
+//go:noescape
+func squareADX(res, a *Field, inv uint64, modulus *Field)
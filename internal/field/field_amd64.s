@@ -0,0 +1,189 @@
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+#include "textflag.h"
+
+// mulADX and squareADX compute the full 512 bit product and then run
+// the same Montgomery reduction as montRed, dropping the same carries,
+// so that they agree with the generic code bit for bit on every input.
+// As with montRed, the result is only correct if the product is less
+// than modulus * 2^256. ADCX and ADOX run two independent carry chains,
+// which lets the low and high halves of the limb products be
+// accumulated in a single pass.
+//
+// The product lives in BX, CX, SI, R10, R11, R12, R13, R14.
+
+// MUL_ROW adds a[i] * b to r_i..r_i+4, where off = 8 * i and a and b
+// are in R8 and R9. r_i+4 is overwritten, the sum cannot carry out of it
+#define MUL_ROW(off, ri, ri1, ri2, ri3, ri4) \
+	XORQ  AX, AX;         \
+	MOVQ  off(R8), DX;    \
+	MULXQ 0(R9), AX, DI;  \
+	ADOXQ AX, ri;         \
+	ADCXQ DI, ri1;        \
+	MULXQ 8(R9), AX, DI;  \
+	ADOXQ AX, ri1;        \
+	ADCXQ DI, ri2;        \
+	MULXQ 16(R9), AX, DI; \
+	ADOXQ AX, ri2;        \
+	ADCXQ DI, ri3;        \
+	MULXQ 24(R9), AX, ri4; \
+	ADOXQ AX, ri3;        \
+	MOVQ  $0, AX;         \
+	ADCXQ AX, ri4;        \
+	ADOXQ AX, ri4
+
+// REDUCE_STEP adds k * modulus to r_i..r_i+4 with k = r_i * inv, which
+// clears r_i. The modulus and inv are in R8 and R9. The carries of both
+// chains are left pending into r_i+5
+#define REDUCE_STEP(ri, ri1, ri2, ri3, ri4) \
+	MOVQ  ri, DX;         \
+	IMULQ R9, DX;         \
+	XORQ  AX, AX;         \
+	MULXQ 0(R8), AX, DI;  \
+	ADOXQ AX, ri;         \
+	ADCXQ DI, ri1;        \
+	MULXQ 8(R8), AX, DI;  \
+	ADOXQ AX, ri1;        \
+	ADCXQ DI, ri2;        \
+	MULXQ 16(R8), AX, DI; \
+	ADOXQ AX, ri2;        \
+	ADCXQ DI, ri3;        \
+	MULXQ 24(R8), AX, DI; \
+	ADOXQ AX, ri3;        \
+	ADCXQ DI, ri4;        \
+	MOVQ  $0, AX;         \
+	ADOXQ AX, ri4
+
+// CARRY propagates the pending carries of both chains into r
+#define CARRY(r) \
+	ADCXQ AX, r; \
+	ADOXQ AX, r
+
+// MONT_REDUCE reduces the product in BX, CX, SI, R10, R11, R12, R13, R14
+// as montRed does, with the modulus in R8 and inv in R9, and stores the
+// result at res
+#define MONT_REDUCE \
+	REDUCE_STEP(BX, CX, SI, R10, R11); \
+	CARRY(R12); \
+	CARRY(R13); \
+	CARRY(R14); \
+	REDUCE_STEP(CX, SI, R10, R11, R12); \
+	CARRY(R13); \
+	CARRY(R14); \
+	REDUCE_STEP(SI, R10, R11, R12, R13); \
+	CARRY(R14); \
+	REDUCE_STEP(R10, R11, R12, R13, R14); \
+	MOVQ R11, AX; \
+	MOVQ R12, BX; \
+	MOVQ R13, CX; \
+	MOVQ R14, SI; \
+	SUBQ 0(R8), AX; \
+	SBBQ 8(R8), BX; \
+	SBBQ 16(R8), CX; \
+	SBBQ 24(R8), SI; \
+	CMOVQCC AX, R11; \
+	CMOVQCC BX, R12; \
+	CMOVQCC CX, R13; \
+	CMOVQCC SI, R14; \
+	MOVQ res+0(FP), AX; \
+	MOVQ R11, 0(AX); \
+	MOVQ R12, 8(AX); \
+	MOVQ R13, 16(AX); \
+	MOVQ R14, 24(AX)
+
+// func mulADX(res, a, b *Field, inv uint64, modulus *Field)
+TEXT ·mulADX(SB), NOSPLIT, $0-40
+	MOVQ a+8(FP), R8
+	MOVQ b+16(FP), R9
+
+	// r0..r4 = a[0] * b
+	MOVQ  0(R8), DX
+	XORQ  AX, AX
+	MULXQ 0(R9), BX, CX
+	MULXQ 8(R9), AX, SI
+	ADCXQ AX, CX
+	MULXQ 16(R9), AX, R10
+	ADCXQ AX, SI
+	MULXQ 24(R9), AX, R11
+	ADCXQ AX, R10
+	MOVQ  $0, AX
+	ADCXQ AX, R11
+
+	MUL_ROW(8, CX, SI, R10, R11, R12)
+	MUL_ROW(16, SI, R10, R11, R12, R13)
+	MUL_ROW(24, R10, R11, R12, R13, R14)
+
+	MOVQ modulus+32(FP), R8
+	MOVQ inv+24(FP), R9
+
+	// Carries out of r7 are dropped, as in montRed
+	MONT_REDUCE
+	RET
+
+// func squareADX(res, a *Field, inv uint64, modulus *Field)
+TEXT ·squareADX(SB), NOSPLIT, $0-32
+	MOVQ a+8(FP), R8
+
+	// The products a[i] * a[j] with i < j into r1..r6
+	MOVQ  0(R8), DX
+	XORQ  AX, AX
+	MULXQ 8(R8), CX, SI
+	MULXQ 16(R8), AX, R10
+	ADCXQ AX, SI
+	MULXQ 24(R8), AX, R11
+	ADCXQ AX, R10
+	MOVQ  $0, AX
+	ADCXQ AX, R11
+
+	MOVQ  8(R8), DX
+	XORQ  AX, AX
+	MULXQ 16(R8), AX, DI
+	ADOXQ AX, R10
+	ADCXQ DI, R11
+	MULXQ 24(R8), AX, R12
+	ADOXQ AX, R11
+	MOVQ  $0, AX
+	ADCXQ AX, R12
+	ADOXQ AX, R12
+
+	MOVQ  16(R8), DX
+	XORQ  AX, AX
+	MULXQ 24(R8), AX, R13
+	ADCXQ AX, R12
+	MOVQ  $0, AX
+	ADCXQ AX, R13
+
+	// Double them into r1..r7
+	XORQ  R14, R14
+	ADCXQ CX, CX
+	ADCXQ SI, SI
+	ADCXQ R10, R10
+	ADCXQ R11, R11
+	ADCXQ R12, R12
+	ADCXQ R13, R13
+	ADCXQ R14, R14
+
+	// Add the squares a[i] * a[i] at r2i
+	XORQ  DI, DI
+	MOVQ  0(R8), DX
+	MULXQ DX, BX, AX
+	ADCXQ AX, CX
+	MOVQ  8(R8), DX
+	MULXQ DX, AX, DI
+	ADCXQ AX, SI
+	ADCXQ DI, R10
+	MOVQ  16(R8), DX
+	MULXQ DX, AX, DI
+	ADCXQ AX, R11
+	ADCXQ DI, R12
+	MOVQ  24(R8), DX
+	MULXQ DX, AX, DI
+	ADCXQ AX, R13
+	ADCXQ DI, R14
+
+	MOVQ modulus+24(FP), R8
+	MOVQ inv+16(FP), R9
+
+	MONT_REDUCE
+	RET
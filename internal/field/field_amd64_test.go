@@ -0,0 +1,108 @@
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+package field
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// edgeFields returns values that stress the carry chains
+func edgeFields(modulus Field) []Field {
+	max := ^uint64(0)
+	return []Field{
+		{},
+		{1, 0, 0, 0},
+		{max, max, max, max},
+		{max, 0, max, 0},
+		{0, 0, 0, 1 << 63},
+		modulus,
+		{modulus[0] - 1, modulus[1], modulus[2], modulus[3]},
+		{modulus[0] + 1, modulus[1], modulus[2], modulus[3]},
+	}
+}
+
+// TestMulADX checks that the assembly agrees with the generic code bit
+// for bit, including on unreduced inputs. TestMulBig checks both against
+// math/big for reduced inputs
+func TestMulADX(t *testing.T) {
+	if !hasADX {
+		t.Skip("BMI2 and ADX are not supported")
+	}
+
+	for _, m := range moduli {
+		var as, bs []Field
+		for _, a := range edgeFields(m.modulus) {
+			for _, b := range edgeFields(m.modulus) {
+				as, bs = append(as, a), append(bs, b)
+			}
+		}
+		for i := 0; i < 1000; i++ {
+			as, bs = append(as, randomField(t)), append(bs, randomField(t))
+		}
+
+		for i := range as {
+			a, b := as[i], bs[i]
+
+			var got, want Field
+			mulADX(&got, &a, &b, m.inv, &m.modulus)
+			want.mulGeneric(a, b, m.inv, m.modulus)
+			assert.Equal(t, want, got, "%s: %x * %x", m.name, a, b)
+
+			squareADX(&got, &a, m.inv, &m.modulus)
+			want.squareGeneric(a, m.inv, m.modulus)
+			assert.Equal(t, want, got, "%s: %x^2", m.name, a)
+		}
+
+		// The result may alias an input
+		a, b := as[len(as)-1], bs[len(bs)-1]
+		var want Field
+		want.mulGeneric(a, b, m.inv, m.modulus)
+		mulADX(&a, &a, &b, m.inv, &m.modulus)
+		assert.Equal(t, want, a)
+
+		want.squareGeneric(a, m.inv, m.modulus)
+		squareADX(&a, &a, m.inv, &m.modulus)
+		assert.Equal(t, want, a)
+	}
+}
+
+func BenchmarkMul(b *testing.B) {
+	m := moduli[0]
+	x, y := randomField(b), randomField(b)
+
+	b.Run("generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.mulGeneric(x, y, m.inv, m.modulus)
+		}
+	})
+	b.Run("adx", func(b *testing.B) {
+		if !hasADX {
+			b.Skip("BMI2 and ADX are not supported")
+		}
+		for i := 0; i < b.N; i++ {
+			mulADX(&x, &x, &y, m.inv, &m.modulus)
+		}
+	})
+}
+
+func BenchmarkSquare(b *testing.B) {
+	m := moduli[0]
+	x := randomField(b)
+
+	b.Run("generic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			x.squareGeneric(x, m.inv, m.modulus)
+		}
+	})
+	b.Run("adx", func(b *testing.B) {
+		if !hasADX {
+			b.Skip("BMI2 and ADX are not supported")
+		}
+		for i := 0; i < b.N; i++ {
+			squareADX(&x, &x, m.inv, &m.modulus)
+		}
+	})
+}
@@ -0,0 +1,14 @@
+//go:build !amd64 || purego
+// +build !amd64 purego
+
+package field
+
+const hasADX = false
+
+func mulADX(res, a, b *Field, inv uint64, modulus *Field) {
+	panic("field: mulADX is only available on amd64")
+}
+
+func squareADX(res, a *Field, inv uint64, modulus *Field) {
+	panic("field: squareADX is only available on amd64")
+}
@@ -0,0 +1,92 @@
+package field
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type modulusParams struct {
+	name    string
+	inv     uint64
+	modulus Field
+}
+
+// The Jubjub base and scalar fields
+var moduli = []modulusParams{
+	{"q", 0xfffffffeffffffff, Field{0xffffffff00000001, 0x53bda402fffe5bfe, 0x3339d80809a1d805, 0x73eda753299d7d48}},
+	{"r", 0x1ba3a358ef788ef9, Field{0xd0970e5ed6f72cb7, 0xa6682093ccc81082, 0x06673b0101343b00, 0x0e7db4ea6533afa9}},
+}
+
+func randomField(t testing.TB) Field {
+	var buf [32]byte
+	_, err := rand.Read(buf[:])
+	assert.Nil(t, err)
+
+	var f Field
+	for i := range f {
+		f[i] = binary.LittleEndian.Uint64(buf[8*i:])
+	}
+	return f
+}
+
+// randomReduced returns a random value less than modulus
+func randomReduced(t testing.TB, modulus Field) Field {
+	x, err := rand.Int(rand.Reader, toBig(modulus))
+	assert.Nil(t, err)
+
+	var f Field
+	for i := range f {
+		f[i] = x.Uint64()
+		x.Rsh(x, 64)
+	}
+	return f
+}
+
+func toBig(f Field) *big.Int {
+	x := new(big.Int)
+	for i := len(f) - 1; i >= 0; i-- {
+		x.Lsh(x, 64)
+		x.Or(x, new(big.Int).SetUint64(f[i]))
+	}
+	return x
+}
+
+// TestMulBig checks Mul and Square against a * b / 2^256 mod p computed
+// with math/big, for reduced inputs
+func TestMulBig(t *testing.T) {
+	for _, m := range moduli {
+		p := toBig(m.modulus)
+		rInv := new(big.Int).Lsh(big.NewInt(1), 256)
+		rInv.ModInverse(rInv, p)
+
+		max := m.modulus
+		max[0]--
+		as := []Field{{}, {1, 0, 0, 0}, max}
+		for i := 0; i < 1000; i++ {
+			as = append(as, randomReduced(t, m.modulus))
+		}
+
+		for i, a := range as {
+			b := as[(i+1)%len(as)]
+
+			want := new(big.Int).Mul(toBig(a), toBig(b))
+			want.Mul(want, rInv).Mod(want, p)
+			var got Field
+			got.Mul(a, b, m.inv, m.modulus)
+			assert.Equal(t, want, toBig(got), "%s: %x * %x", m.name, a, b)
+			got.mulGeneric(a, b, m.inv, m.modulus)
+			assert.Equal(t, want, toBig(got), "%s: %x * %x", m.name, a, b)
+
+			want.Mul(toBig(a), toBig(a))
+			want.Mul(want, rInv).Mod(want, p)
+			got.Square(a, m.inv, m.modulus)
+			assert.Equal(t, want, toBig(got), "%s: %x^2", m.name, a)
+			got.squareGeneric(a, m.inv, m.modulus)
+			assert.Equal(t, want, toBig(got), "%s: %x^2", m.name, a)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustModulus(t *testing.T, s string) *big.Int {
+	p, err := parseModulus(s)
+	assert.Nil(t, err)
+	return p
+}
+
+// constants returns the integer constants and limb arrays declared in
+// the Go file at path, by name
+func constants(t *testing.T, path string) map[string][]uint64 {
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	assert.Nil(t, err)
+
+	parse := func(e ast.Expr) uint64 {
+		lit, ok := e.(*ast.BasicLit)
+		assert.True(t, ok, "%s: not a literal", path)
+		v, err := strconv.ParseUint(lit.Value, 0, 64)
+		assert.Nil(t, err)
+		return v
+	}
+
+	res := make(map[string][]uint64)
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok || len(vs.Values) != 1 {
+				continue
+			}
+			switch v := vs.Values[0].(type) {
+			case *ast.BasicLit:
+				res[vs.Names[0].Name] = []uint64{parse(v)}
+			case *ast.CompositeLit:
+				var l []uint64
+				for _, e := range v.Elts {
+					l = append(l, parse(e))
+				}
+				res[vs.Names[0].Name] = l
+			}
+		}
+	}
+	return res
+}
+
+// fromLimbs returns the integer with the given little endian limbs
+func fromLimbs(l []uint64) *big.Int {
+	x := new(big.Int)
+	for i := len(l) - 1; i >= 0; i-- {
+		x.Lsh(x, 64)
+		x.Or(x, new(big.Int).SetUint64(l[i]))
+	}
+	return x
+}
+
+// The generator reproduces the constants of internal/Fr
+func TestParamsFr(t *testing.T) {
+	c := constants(t, filepath.Join("..", "Fr", "const.go"))
+	pp, err := newParams("fr", "Element", fromLimbs(c["rMod"]))
+	assert.Nil(t, err)
+
+	assert.Equal(t, 4, pp.N)
+	assert.Equal(t, c["INV"][0], pp.Inv)
+	assert.Equal(t, c["montR"], pp.R)
+	assert.Equal(t, c["r2"], pp.R2)
+	assert.Equal(t, c["r3"], pp.R3)
+	assert.Equal(t, c["rMinus2"], pp.PMinus2)
+	assert.True(t, pp.Sqrt3Mod4)
+	assert.Equal(t, c["rPlus1Div4"], pp.SqrtExp)
+}
+
+// The generator reproduces the constants of internal/Fq
+func TestParamsFq(t *testing.T) {
+	c := constants(t, filepath.Join("..", "Fq", "const.go"))
+	pp, err := newParams("fq", "Element", fromLimbs(c["qMod"]))
+	assert.Nil(t, err)
+
+	assert.Equal(t, c["INV"][0], pp.Inv)
+	assert.Equal(t, c["R"], pp.R)
+	assert.Equal(t, c["R2"], pp.R2)
+	assert.Equal(t, c["R3"], pp.R3)
+	assert.False(t, pp.Sqrt3Mod4)
+	assert.Equal(t, int(c["S"][0]), pp.S)
+
+	// internal/Fq takes the root of unity from the multiplicative
+	// generator 7 and the generator from the smallest non-residue 5,
+	// both are primitive 2^S-th roots of unity in Montgomery form
+	p := pp.Modulus
+	rInv := new(big.Int).ModInverse(fromLimbs(pp.R), p)
+	minusOne := new(big.Int).Sub(p, one)
+	for _, root := range [][]uint64{c["ROOTOFUNITY"], pp.RootOfUnity} {
+		x := new(big.Int).Mul(fromLimbs(root), rInv)
+		x.Exp(x, new(big.Int).Lsh(one, uint(pp.S-1)), p)
+		assert.Equal(t, minusOne, x)
+	}
+}
+
+func TestParamsErrors(t *testing.T) {
+	for _, s := range []string{"0", "1", "2", "-7", "15", "0x10001000000000000000000000000000000000001"} {
+		_, err := newParams("p", "Element", mustModulus(t, s))
+		assert.NotNil(t, err, s)
+	}
+
+	_, err := parseModulus("0xzz")
+	assert.NotNil(t, err)
+	assert.NotNil(t, run("7", "", "Element", t.TempDir()))
+}
+
+// The committed example fields must match the generator
+func TestGeneratedUpToDate(t *testing.T) {
+	fields := []struct{ pkg, modulus string }{
+		{"bn254fr", "21888242871839275222246405745257275088548364400416034343698204186575808495617"},
+		{"bls12381fp", "0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab"},
+	}
+
+	for _, f := range fields {
+		dir := t.TempDir()
+		assert.Nil(t, run(f.modulus, f.pkg, "Element", dir))
+
+		for _, name := range []string{"element.go", "element_test.go"} {
+			got, err := ioutil.ReadFile(filepath.Join(dir, name))
+			assert.Nil(t, err)
+			want, err := ioutil.ReadFile(filepath.Join("..", "fields", f.pkg, name))
+			assert.Nil(t, err)
+			assert.Equal(t, string(want), string(got), "%s/%s is stale, run go generate", f.pkg, name)
+		}
+	}
+}
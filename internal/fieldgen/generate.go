@@ -0,0 +1,653 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+// writer accumulates generated source
+type writer struct {
+	bytes.Buffer
+}
+
+func (w *writer) p(format string, args ...interface{}) {
+	fmt.Fprintf(w, format, args...)
+	w.WriteByte('\n')
+}
+
+// hexLimbs formats limbs as the elements of a composite literal
+func hexLimbs(l []uint64) string {
+	s := make([]string, len(l))
+	for i, v := range l {
+		s[i] = fmt.Sprintf("0x%016x", v)
+	}
+	return strings.Join(s, ", ")
+}
+
+// vars returns "prefix0, prefix1, ..., prefix(n-1)"
+func vars(prefix string, n int) string {
+	s := make([]string, n)
+	for i := range s {
+		s[i] = fmt.Sprintf("%s%d", prefix, i)
+	}
+	return strings.Join(s, ", ")
+}
+
+// generate returns the formatted source of the field and of its tests
+func generate(pp *params) (src, test []byte, err error) {
+	src, err = format.Source(generateField(pp))
+	if err != nil {
+		return nil, nil, fmt.Errorf("formatting field: %v", err)
+	}
+	test, err = format.Source(generateTest(pp))
+	if err != nil {
+		return nil, nil, fmt.Errorf("formatting tests: %v", err)
+	}
+	return src, test, nil
+}
+
+const header = "// Code generated by internal/fieldgen. DO NOT EDIT.\n"
+
+func generateField(pp *params) []byte {
+	var w writer
+	n, T := pp.N, pp.Type
+
+	w.p(header)
+	w.p("package %s", pp.Package)
+	w.p("")
+	w.p("import (")
+	w.p(`"encoding/hex"`)
+	w.p(`"errors"`)
+	w.p(`"math/bits"`)
+	w.p(")")
+	w.p("")
+	w.p("// Limbs is the number of 64 bit limbs of an element")
+	w.p("const Limbs = %d", n)
+	w.p("")
+	w.p("// Bytes is the size of the encoding of an element")
+	w.p("const Bytes = %d", 8*n)
+	w.p("")
+	w.p("// %s is an element of the field of integers modulo", T)
+	w.p("// p = 0x%s", pp.Modulus.Text(16))
+	w.p("// in Montgomery form, as little endian 64 bit limbs.")
+	w.p("// All operations run in constant time")
+	w.p("type %s [%d]uint64", T, n)
+	w.p("")
+	w.p("// inv = -(p^{-1} mod 2^64) mod 2^64")
+	w.p("const inv uint64 = 0x%016x", pp.Inv)
+	w.p("")
+	w.p("var (")
+	w.p("// modulus is p")
+	w.p("modulus = %s{%s}", T, hexLimbs(pp.Limbs))
+	w.p("")
+	w.p("// rOne = 2^%d mod p, the Montgomery form of one", 64*n)
+	w.p("rOne = %s{%s}", T, hexLimbs(pp.R))
+	w.p("")
+	w.p("// r2 = 2^%d mod p", 128*n)
+	w.p("r2 = %s{%s}", T, hexLimbs(pp.R2))
+	w.p("")
+	w.p("// r3 = 2^%d mod p", 192*n)
+	w.p("r3 = %s{%s}", T, hexLimbs(pp.R3))
+	w.p("")
+	w.p("// pMinus2 = p - 2 is the exponent of inversion")
+	w.p("pMinus2 = [%d]uint64{%s}", n, hexLimbs(pp.PMinus2))
+	w.p("")
+	if pp.Sqrt3Mod4 {
+		w.p("// sqrtExp = (p + 1) / 4 is the exponent of the square root")
+	} else {
+		w.p("// sqrtExp = (t - 1) / 2, where p - 1 = 2^s * t with t odd")
+	}
+	w.p("sqrtExp = [%d]uint64{%s}", n, hexLimbs(pp.SqrtExp))
+	if !pp.Sqrt3Mod4 {
+		w.p("")
+		w.p("// rootOfUnity = g^t for the smallest non-residue g, a primitive 2^s-th root of unity")
+		w.p("rootOfUnity = %s{%s}", T, hexLimbs(pp.RootOfUnity))
+	}
+	w.p(")")
+	if !pp.Sqrt3Mod4 {
+		w.p("")
+		w.p("// s is the 2-adicity of p - 1")
+		w.p("const s = %d", pp.S)
+	}
+	w.p("")
+
+	w.p(`// SetZero sets f = 0
+func (f *%[1]s) SetZero() *%[1]s {
+	*f = %[1]s{}
+	return f
+}
+
+// SetOne sets f = 1
+func (f *%[1]s) SetOne() *%[1]s {
+	*f = rOne
+	return f
+}
+
+// Set sets f = a
+func (f *%[1]s) Set(a %[1]s) *%[1]s {
+	*f = a
+	return f
+}
+
+// FromU64 sets f = a
+func (f *%[1]s) FromU64(a uint64) *%[1]s {
+	return f.Mul(%[1]s{a}, r2)
+}
+
+// IsZero returns 1 if f = 0 and 0 otherwise
+func (f *%[1]s) IsZero() uint64 {
+	return ConstantTimeEq(*f, %[1]s{})
+}
+`, T)
+
+	// ConstantTimeEq
+	w.p("// ConstantTimeEq returns 1 if a = b and 0 otherwise")
+	w.p("func ConstantTimeEq(a, b %s) uint64 {", T)
+	w.p("var d uint64")
+	for i := 0; i < n; i++ {
+		w.p("d |= a[%d] ^ b[%d]", i, i)
+	}
+	w.p("// d | -d has its top bit set if and only if d != 0")
+	w.p("return ((d | -d) >> 63) ^ 1")
+	w.p("}")
+	w.p("")
+
+	// CondSel
+	w.p("// CondSel sets f to a if c = 1 or to b if c = 0")
+	w.p("func (f *%[1]s) CondSel(a, b %[1]s, c uint64) *%[1]s {", T)
+	w.p("mask := -c")
+	for i := 0; i < n; i++ {
+		w.p("f[%d] = b[%d] ^ (mask & (a[%d] ^ b[%d]))", i, i, i, i)
+	}
+	w.p("return f")
+	w.p("}")
+	w.p("")
+
+	// Add
+	w.p("// Add sets f = a + b")
+	w.p("func (f *%[1]s) Add(a, b %[1]s) *%[1]s {", T)
+	w.p("var carry uint64")
+	for i := 0; i < n; i++ {
+		w.p("z%d, carry := bits.Add64(a[%d], b[%d], carry)", i, i, i)
+	}
+	w.p("f.reduce(%s, carry)", vars("z", n))
+	w.p("return f")
+	w.p("}")
+	w.p("")
+
+	// reduce: conditional subtraction of the modulus
+	w.p("// reduce sets f = z - p if z >= p and f = z otherwise, for z < 2p")
+	w.p("// given as n limbs and a carry bit")
+	w.p("func (f *%s) reduce(%s, carry uint64) {", T, vars("z", n))
+	w.p("var borrow uint64")
+	for i := 0; i < n; i++ {
+		w.p("d%d, borrow := bits.Sub64(z%d, modulus[%d], borrow)", i, i, i)
+	}
+	w.p("// keep z if and only if z - p borrowed and there was no carry")
+	w.p("mask := -(borrow &^ carry)")
+	for i := 0; i < n; i++ {
+		w.p("f[%d] = d%d ^ (mask & (z%d ^ d%d))", i, i, i, i)
+	}
+	w.p("}")
+	w.p("")
+
+	// Sub
+	w.p("// Sub sets f = a - b")
+	w.p("func (f *%[1]s) Sub(a, b %[1]s) *%[1]s {", T)
+	w.p("var borrow uint64")
+	for i := 0; i < n; i++ {
+		w.p("z%d, borrow := bits.Sub64(a[%d], b[%d], borrow)", i, i, i)
+	}
+	w.p("// add p back if the subtraction borrowed")
+	w.p("mask := -borrow")
+	w.p("var carry uint64")
+	for i := 0; i < n; i++ {
+		w.p("f[%d], carry = bits.Add64(z%d, modulus[%d]&mask, carry)", i, i, i)
+	}
+	w.p("return f")
+	w.p("}")
+	w.p("")
+
+	w.p(`// Double sets f = 2a
+func (f *%[1]s) Double(a %[1]s) *%[1]s {
+	return f.Add(a, a)
+}
+
+// Neg sets f = -a
+func (f *%[1]s) Neg(a %[1]s) *%[1]s {
+	return f.Sub(%[1]s{}, a)
+}
+`, T)
+
+	emitMul(&w, pp)
+
+	w.p(`// Square sets f = a * a
+func (f *%[1]s) Square(a %[1]s) *%[1]s {
+	return f.Mul(a, a)
+}
+
+// expByVarTime sets f = a^e. It is variable time in e, which must be public
+func (f *%[1]s) expByVarTime(a %[1]s, e [%[2]d]uint64) *%[1]s {
+	res := rOne
+	for i := len(e) - 1; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			res.Square(res)
+			if (e[i]>>uint(j))&1 == 1 {
+				res.Mul(res, a)
+			}
+		}
+	}
+	*f = res
+	return f
+}
+
+// Invert sets f = 1/a, computed as a^(p-2). The inverse of zero is zero
+func (f *%[1]s) Invert(a %[1]s) *%[1]s {
+	return f.expByVarTime(a, pMinus2)
+}
+`, T, n)
+
+	if pp.Sqrt3Mod4 {
+		w.p(`// Sqrt sets f to a square root of a and returns 1 if a is a square.
+// Otherwise f is set to garbage and 0 is returned.
+// As p = 3 mod 4 the root is a^((p+1)/4)
+func (f *%[1]s) Sqrt(a %[1]s) uint64 {
+	var root, check %[1]s
+	root.expByVarTime(a, sqrtExp)
+	check.Square(root)
+
+	*f = root
+	return ConstantTimeEq(check, a)
+}
+`, T)
+	} else {
+		w.p(`// Sqrt sets f to a square root of a and returns 1 if a is a square.
+// Otherwise f is set to garbage and 0 is returned.
+// Uses the constant time variant of Tonelli-Shanks
+func (f *%[1]s) Sqrt(a %[1]s) uint64 {
+	var z, t, b, c, tmp %[1]s
+
+	// z = a^((t - 1) / 2)
+	z.expByVarTime(a, sqrtExp)
+
+	t.Square(z)
+	t.Mul(t, a) // a^t
+	z.Mul(z, a) // a^((t + 1) / 2)
+	b = t
+	c = rootOfUnity
+
+	for i := s; i >= 2; i-- {
+		for j := 1; j <= i-2; j++ {
+			b.Square(b)
+		}
+		e := ConstantTimeEq(b, rOne)
+
+		tmp.Mul(z, c)
+		z.CondSel(z, tmp, e)
+		c.Square(c)
+		tmp.Mul(t, c)
+		t.CondSel(t, tmp, e)
+		b = t
+	}
+
+	tmp.Square(z)
+	*f = z
+	return ConstantTimeEq(tmp, a)
+}
+`, T)
+	}
+
+	// Encoding
+	w.p(`// BytesInto sets buf to the canonical little endian encoding of f
+func (f *%[1]s) BytesInto(buf *[Bytes]byte) {
+	// Multiplying by one in normal form leaves Montgomery form
+	var t %[1]s
+	t.Mul(*f, %[1]s{1})
+	for i := range t {
+		for j := 0; j < 8; j++ {
+			buf[8*i+j] = byte(t[i] >> uint(8*j))
+		}
+	}
+}
+
+// IntoBytes returns the canonical little endian encoding of f
+func (f *%[1]s) IntoBytes() []byte {
+	var buf [Bytes]byte
+	f.BytesInto(&buf)
+	return buf[:]
+}
+
+// load returns the little endian limbs of b
+func load(b []byte) %[1]s {
+	var t %[1]s
+	for i := range t {
+		for j := 0; j < 8; j++ {
+			t[i] |= uint64(b[8*i+j]) << uint(8*j)
+		}
+	}
+	return t
+}
+
+// SetBytes sets f to the canonical little endian encoding in buf.
+// Returns an error, leaving f unchanged, if the value is not less than p
+func (f *%[1]s) SetBytes(buf *[Bytes]byte) (*%[1]s, error) {
+	t := load(buf[:])
+
+	var borrow uint64
+	for i := range t {
+		_, borrow = bits.Sub64(t[i], modulus[i], borrow)
+	}
+	if borrow == 0 {
+		return f, errors.New("field element is not canonical")
+	}
+
+	return f.Mul(t, r2), nil
+}
+
+// FromBytes sets f to the little endian value in byt reduced mod p.
+// If byt is uniformly random, the bias of f is negligible
+func (f *%[1]s) FromBytes(byt [2 * Bytes]byte) *%[1]s {
+	// lo * R + hi * 2^%[2]d * R, as Montgomery multiplication divides by R
+	var lo, hi %[1]s
+	lo.Mul(load(byt[:Bytes]), r2)
+	hi.Mul(load(byt[Bytes:]), r3)
+	return f.Add(lo, hi)
+}
+
+// String returns the big endian hex encoding of f
+func (f *%[1]s) String() string {
+	var buf [Bytes]byte
+	f.BytesInto(&buf)
+
+	// reverse bytes
+	for i, j := 0, len(buf)-1; i < j; i, j = i+1, j-1 {
+		buf[i], buf[j] = buf[j], buf[i]
+	}
+
+	return "0x" + hex.EncodeToString(buf[:])
+}
+
+// madd1 returns a * b + c
+func madd1(a, b, c uint64) (hi, lo uint64) {
+	var carry uint64
+	hi, lo = bits.Mul64(a, b)
+	lo, carry = bits.Add64(lo, c, 0)
+	hi += carry
+	return hi, lo
+}
+
+// madd2 returns a * b + c + d, which cannot overflow 128 bits
+func madd2(a, b, c, d uint64) (hi, lo uint64) {
+	var carry uint64
+	hi, lo = bits.Mul64(a, b)
+	c, carry = bits.Add64(c, d, 0)
+	hi += carry
+	lo, carry = bits.Add64(lo, c, 0)
+	hi += carry
+	return hi, lo
+}`, T, 64*n)
+
+	return w.Bytes()
+}
+
+// emitMul writes a fully unrolled Montgomery multiplication with the
+// coarsely integrated operand scanning (CIOS) method
+func emitMul(w *writer, pp *params) {
+	n, T := pp.N, pp.Type
+
+	w.p("// Mul sets f = a * b")
+	w.p("func (f *%[1]s) Mul(a, b %[1]s) *%[1]s {", T)
+	w.p("var %s, c, m uint64", vars("t", n+2))
+	for i := 0; i < n; i++ {
+		w.p("")
+		w.p("// t += a * b[%d]", i)
+		w.p("c, t0 = madd1(a[0], b[%d], t0)", i)
+		for j := 1; j < n; j++ {
+			w.p("c, t%d = madd2(a[%d], b[%d], t%d, c)", j, j, i, j)
+		}
+		w.p("t%d, t%d = bits.Add64(t%d, c, 0)", n, n+1, n)
+		w.p("")
+		w.p("// t = (t + m * p) / 2^64, where m makes the low limb vanish")
+		w.p("m = t0 * inv")
+		w.p("c, _ = madd1(m, modulus[0], t0)")
+		for j := 1; j < n; j++ {
+			w.p("c, t%d = madd2(m, modulus[%d], t%d, c)", j-1, j, j)
+		}
+		w.p("t%d, c = bits.Add64(t%d, c, 0)", n-1, n)
+		w.p("t%d = t%d + c", n, n+1)
+	}
+	w.p("")
+	w.p("// t < 2p")
+	w.p("f.reduce(%s, t%d)", vars("t", n), n)
+	w.p("return f")
+	w.p("}")
+	w.p("")
+}
+
+func generateTest(pp *params) []byte {
+	var w writer
+	T := pp.Type
+
+	w.p(header)
+	w.p(`package %[1]s
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+var modulusBig, _ = new(big.Int).SetString("%[2]s", 16)
+
+// fromBig returns x mod p
+func fromBig(x *big.Int) %[3]s {
+	x = new(big.Int).Mod(x, modulusBig)
+
+	var buf [Bytes]byte
+	b := x.Bytes()
+	for i := range b {
+		buf[i] = b[len(b)-1-i]
+	}
+
+	var f %[3]s
+	if _, err := f.SetBytes(&buf); err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// toBig returns the canonical value of f
+func toBig(f %[3]s) *big.Int {
+	b := f.IntoBytes()
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return new(big.Int).SetBytes(b)
+}
+
+func random(t testing.TB) (%[3]s, *big.Int) {
+	x, err := rand.Int(rand.Reader, modulusBig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fromBig(x), x
+}
+
+// edge returns values near 0 and p, where carries and borrows happen
+func edge() []*big.Int {
+	one := big.NewInt(1)
+	return []*big.Int{
+		big.NewInt(0),
+		one,
+		big.NewInt(2),
+		new(big.Int).Sub(modulusBig, one),
+		new(big.Int).Sub(modulusBig, big.NewInt(2)),
+		new(big.Int).Rsh(modulusBig, 1),
+	}
+}
+
+func check(t *testing.T, op string, got %[3]s, want *big.Int) {
+	t.Helper()
+	want = new(big.Int).Mod(want, modulusBig)
+	if toBig(got).Cmp(want) != 0 {
+		t.Fatalf("%%s: got %%v, want %%x", op, got.String(), want)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	var as, bs []*big.Int
+	for _, x := range edge() {
+		for _, y := range edge() {
+			as, bs = append(as, x), append(bs, y)
+		}
+	}
+	for i := 0; i < 256; i++ {
+		_, x := random(t)
+		_, y := random(t)
+		as, bs = append(as, x), append(bs, y)
+	}
+
+	for i := range as {
+		x, y := as[i], bs[i]
+		a, b := fromBig(x), fromBig(y)
+
+		var f %[3]s
+		check(t, "add", *f.Add(a, b), new(big.Int).Add(x, y))
+		check(t, "sub", *f.Sub(a, b), new(big.Int).Sub(x, y))
+		check(t, "mul", *f.Mul(a, b), new(big.Int).Mul(x, y))
+		check(t, "square", *f.Square(a), new(big.Int).Mul(x, x))
+		check(t, "double", *f.Double(a), new(big.Int).Lsh(x, 1))
+		check(t, "neg", *f.Neg(a), new(big.Int).Neg(x))
+	}
+}
+
+func TestConstants(t *testing.T) {
+	var f, one %[3]s
+	one.SetOne()
+	check(t, "one", one, big.NewInt(1))
+	check(t, "from u64", *f.FromU64(1<<64-1), new(big.Int).SetUint64(1<<64-1))
+
+	if one.IsZero() != 0 || f.SetZero().IsZero() != 1 {
+		t.Fatal("IsZero")
+	}
+	if ConstantTimeEq(one, one) != 1 || ConstantTimeEq(one, f) != 0 {
+		t.Fatal("ConstantTimeEq")
+	}
+}
+
+func TestInvert(t *testing.T) {
+	var f, one %[3]s
+	one.SetOne()
+
+	f.Invert(%[3]s{})
+	check(t, "invert zero", f, big.NewInt(0))
+
+	for i := 0; i < 32; i++ {
+		a, x := random(t)
+		f.Invert(a)
+		check(t, "invert", f, new(big.Int).ModInverse(x, modulusBig))
+	}
+}
+
+func TestSqrt(t *testing.T) {
+	var root, sq %[3]s
+	if root.Sqrt(%[3]s{}) != 1 || root.IsZero() != 1 {
+		t.Fatal("sqrt of zero")
+	}
+
+	var squares, nonSquares int
+	for i := 0; i < 64; i++ {
+		a, x := random(t)
+		ok := root.Sqrt(a)
+
+		isSquare := big.Jacobi(x, modulusBig) == 1
+		if isSquare != (ok == 1) {
+			t.Fatalf("sqrt of %%x: got %%d", x, ok)
+		}
+		if isSquare {
+			squares++
+			check(t, "sqrt", *sq.Square(root), x)
+		} else {
+			nonSquares++
+		}
+	}
+	if squares == 0 || nonSquares == 0 {
+		t.Fatal("unlucky sample")
+	}
+}
+
+func TestBytes(t *testing.T) {
+	var f %[3]s
+	for i := 0; i < 32; i++ {
+		a, _ := random(t)
+
+		var buf [Bytes]byte
+		a.BytesInto(&buf)
+		if _, err := f.SetBytes(&buf); err != nil || f != a {
+			t.Fatalf("round trip of %%v", a.String())
+		}
+	}
+
+	// p is rejected, leaving f unchanged
+	var buf [Bytes]byte
+	for i, b := range modulusBig.Bytes() {
+		buf[len(modulusBig.Bytes())-1-i] = b
+	}
+	before := f
+	if _, err := f.SetBytes(&buf); err == nil || f != before {
+		t.Fatal("accepted non-canonical encoding")
+	}
+}
+
+func TestFromBytes(t *testing.T) {
+	var f %[3]s
+	for i := 0; i < 32; i++ {
+		var buf [2 * Bytes]byte
+		rand.Read(buf[:])
+		if i == 0 {
+			for j := range buf {
+				buf[j] = 0xff
+			}
+		}
+
+		le := make([]byte, len(buf))
+		for j := range buf {
+			le[len(buf)-1-j] = buf[j]
+		}
+		check(t, "from bytes", *f.FromBytes(buf), new(big.Int).SetBytes(le))
+	}
+}
+
+func BenchmarkMul(b *testing.B) {
+	x, _ := random(b)
+	y, _ := random(b)
+	for i := 0; i < b.N; i++ {
+		x.Mul(x, y)
+	}
+}
+
+func BenchmarkSquare(b *testing.B) {
+	x, _ := random(b)
+	for i := 0; i < b.N; i++ {
+		x.Square(x)
+	}
+}
+
+func BenchmarkInvert(b *testing.B) {
+	x, _ := random(b)
+	for i := 0; i < b.N; i++ {
+		x.Invert(x)
+	}
+}
+
+func BenchmarkSqrt(b *testing.B) {
+	x, _ := random(b)
+	for i := 0; i < b.N; i++ {
+		x.Sqrt(x)
+	}
+}`, pp.Package, pp.Modulus.Text(16), T)
+
+	return w.Bytes()
+}
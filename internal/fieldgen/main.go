@@ -0,0 +1,79 @@
+// Command fieldgen generates a specialized Montgomery field type for a prime
+// modulus, with its constants computed from the modulus, and tests and
+// benchmarks against math/big.
+//
+// Usage, typically from a go:generate line:
+//
+//	go run ./internal/fieldgen -modulus 0x73ed... -package fp -out ./internal/fields/fp
+//
+// The modulus is read as decimal unless it has a 0x prefix. The output
+// directory receives element.go and element_test.go.
+//
+// The tool is for adding new fields. internal/Fq and internal/Fr keep their
+// hand-written constants, which the tests check against the generated ones
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var (
+		modulus = flag.String("modulus", "", "prime modulus, decimal or hex with a 0x prefix")
+		pkg     = flag.String("package", "", "package name of the generated code")
+		typ     = flag.String("type", "Element", "name of the generated field type")
+		out     = flag.String("out", ".", "output directory")
+	)
+	flag.Parse()
+
+	if err := run(*modulus, *pkg, *typ, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "fieldgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(modulus, pkg, typ, out string) error {
+	if pkg == "" {
+		return errors.New("missing -package")
+	}
+	p, err := parseModulus(modulus)
+	if err != nil {
+		return err
+	}
+	pp, err := newParams(pkg, typ, p)
+	if err != nil {
+		return err
+	}
+	src, test, err := generate(pp)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(out, 0755); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(out, "element.go"), src, 0644); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(out, "element_test.go"), test, 0644)
+}
+
+// parseModulus parses a decimal or 0x prefixed hex integer
+func parseModulus(s string) (*big.Int, error) {
+	base := 10
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s, base = s[2:], 16
+	}
+	p, ok := new(big.Int).SetString(s, base)
+	if !ok {
+		return nil, fmt.Errorf("invalid modulus %q", s)
+	}
+	return p, nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+)
+
+// params are the constants of a generated field, all computed from the
+// modulus. Multi-limb values are little endian 64 bit limbs
+type params struct {
+	Package string
+	Type    string
+
+	// N is the number of 64 bit limbs
+	N       int
+	Modulus *big.Int
+	Limbs   []uint64
+
+	// Inv = -(p^{-1} mod 2^64) mod 2^64
+	Inv uint64
+	// R = 2^(64N) mod p, the Montgomery form of one
+	R []uint64
+	// R2 = 2^(128N) mod p, used to convert into Montgomery form
+	R2 []uint64
+	// R3 = 2^(192N) mod p, used for the wide reduction
+	R3 []uint64
+
+	// PMinus2 is the exponent of inversion
+	PMinus2 []uint64
+
+	// If p = 3 mod 4 the square root is a^((p+1)/4), otherwise it is
+	// computed with Tonelli-Shanks where p - 1 = 2^S * t with t odd
+	Sqrt3Mod4 bool
+	// SqrtExp is (p+1)/4 or (t-1)/2
+	SqrtExp []uint64
+	S       int
+	// RootOfUnity is g^t in Montgomery form, for the smallest
+	// non-residue g
+	RootOfUnity []uint64
+}
+
+var (
+	one = big.NewInt(1)
+	two = big.NewInt(2)
+)
+
+// newParams computes the constants of the field of integers modulo p
+func newParams(pkg, typ string, p *big.Int) (*params, error) {
+	if p.Sign() <= 0 || p.Bit(0) == 0 || p.Cmp(big.NewInt(3)) < 0 {
+		return nil, errors.New("modulus must be an odd prime")
+	}
+	if !p.ProbablyPrime(32) {
+		return nil, errors.New("modulus is not prime")
+	}
+
+	n := (p.BitLen() + 63) / 64
+	pp := &params{
+		Package: pkg,
+		Type:    typ,
+		N:       n,
+		Modulus: new(big.Int).Set(p),
+		Limbs:   limbs(p, n),
+	}
+
+	// -p^{-1} mod 2^64
+	word := new(big.Int).Lsh(one, 64)
+	inv := new(big.Int).ModInverse(new(big.Int).Mod(p, word), word)
+	inv.Sub(word, inv)
+	pp.Inv = inv.Uint64()
+
+	r := new(big.Int).Lsh(one, uint(64*n))
+	r.Mod(r, p)
+	pp.R = limbs(r, n)
+	r2 := new(big.Int).Mul(r, r)
+	r2.Mod(r2, p)
+	pp.R2 = limbs(r2, n)
+	r3 := new(big.Int).Mul(r2, r)
+	r3.Mod(r3, p)
+	pp.R3 = limbs(r3, n)
+
+	pp.PMinus2 = limbs(new(big.Int).Sub(p, two), n)
+
+	if p.Bit(1) == 1 {
+		pp.Sqrt3Mod4 = true
+		e := new(big.Int).Add(p, one)
+		pp.SqrtExp = limbs(e.Rsh(e, 2), n)
+		return pp, nil
+	}
+
+	// p - 1 = 2^S * t
+	t := new(big.Int).Sub(p, one)
+	for t.Bit(0) == 0 {
+		t.Rsh(t, 1)
+		pp.S++
+	}
+	e := new(big.Int).Sub(t, one)
+	pp.SqrtExp = limbs(e.Rsh(e, 1), n)
+
+	g := big.NewInt(2)
+	for big.Jacobi(g, p) != -1 {
+		g.Add(g, one)
+	}
+	root := new(big.Int).Exp(g, t, p)
+	root.Mul(root, r)
+	root.Mod(root, p)
+	pp.RootOfUnity = limbs(root, n)
+
+	return pp, nil
+}
+
+// limbs returns the n little endian 64 bit limbs of x
+func limbs(x *big.Int, n int) []uint64 {
+	out := make([]uint64, n)
+	mask := new(big.Int).SetUint64(^uint64(0))
+	y := new(big.Int).Set(x)
+	for i := range out {
+		out[i] = new(big.Int).And(y, mask).Uint64()
+		y.Rsh(y, 64)
+	}
+	return out
+}
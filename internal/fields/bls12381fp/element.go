@@ -0,0 +1,416 @@
+// Code generated by internal/fieldgen. DO NOT EDIT.
+
+package bls12381fp
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/bits"
+)
+
+// Limbs is the number of 64 bit limbs of an element
+const Limbs = 6
+
+// Bytes is the size of the encoding of an element
+const Bytes = 48
+
+// Element is an element of the field of integers modulo
+// p = 0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab
+// in Montgomery form, as little endian 64 bit limbs.
+// All operations run in constant time
+type Element [6]uint64
+
+// inv = -(p^{-1} mod 2^64) mod 2^64
+const inv uint64 = 0x89f3fffcfffcfffd
+
+var (
+	// modulus is p
+	modulus = Element{0xb9feffffffffaaab, 0x1eabfffeb153ffff, 0x6730d2a0f6b0f624, 0x64774b84f38512bf, 0x4b1ba7b6434bacd7, 0x1a0111ea397fe69a}
+
+	// rOne = 2^384 mod p, the Montgomery form of one
+	rOne = Element{0x760900000002fffd, 0xebf4000bc40c0002, 0x5f48985753c758ba, 0x77ce585370525745, 0x5c071a97a256ec6d, 0x15f65ec3fa80e493}
+
+	// r2 = 2^768 mod p
+	r2 = Element{0xf4df1f341c341746, 0x0a76e6a609d104f1, 0x8de5476c4c95b6d5, 0x67eb88a9939d83c0, 0x9a793e85b519952d, 0x11988fe592cae3aa}
+
+	// r3 = 2^1152 mod p
+	r3 = Element{0xed48ac6bd94ca1e0, 0x315f831e03a7adf8, 0x9a53352a615e29dd, 0x34c04e5e921e1761, 0x2512d43565724728, 0x0aa6346091755d4d}
+
+	// pMinus2 = p - 2 is the exponent of inversion
+	pMinus2 = [6]uint64{0xb9feffffffffaaa9, 0x1eabfffeb153ffff, 0x6730d2a0f6b0f624, 0x64774b84f38512bf, 0x4b1ba7b6434bacd7, 0x1a0111ea397fe69a}
+
+	// sqrtExp = (p + 1) / 4 is the exponent of the square root
+	sqrtExp = [6]uint64{0xee7fbfffffffeaab, 0x07aaffffac54ffff, 0xd9cc34a83dac3d89, 0xd91dd2e13ce144af, 0x92c6e9ed90d2eb35, 0x0680447a8e5ff9a6}
+)
+
+// SetZero sets f = 0
+func (f *Element) SetZero() *Element {
+	*f = Element{}
+	return f
+}
+
+// SetOne sets f = 1
+func (f *Element) SetOne() *Element {
+	*f = rOne
+	return f
+}
+
+// Set sets f = a
+func (f *Element) Set(a Element) *Element {
+	*f = a
+	return f
+}
+
+// FromU64 sets f = a
+func (f *Element) FromU64(a uint64) *Element {
+	return f.Mul(Element{a}, r2)
+}
+
+// IsZero returns 1 if f = 0 and 0 otherwise
+func (f *Element) IsZero() uint64 {
+	return ConstantTimeEq(*f, Element{})
+}
+
+// ConstantTimeEq returns 1 if a = b and 0 otherwise
+func ConstantTimeEq(a, b Element) uint64 {
+	var d uint64
+	d |= a[0] ^ b[0]
+	d |= a[1] ^ b[1]
+	d |= a[2] ^ b[2]
+	d |= a[3] ^ b[3]
+	d |= a[4] ^ b[4]
+	d |= a[5] ^ b[5]
+	// d | -d has its top bit set if and only if d != 0
+	return ((d | -d) >> 63) ^ 1
+}
+
+// CondSel sets f to a if c = 1 or to b if c = 0
+func (f *Element) CondSel(a, b Element, c uint64) *Element {
+	mask := -c
+	f[0] = b[0] ^ (mask & (a[0] ^ b[0]))
+	f[1] = b[1] ^ (mask & (a[1] ^ b[1]))
+	f[2] = b[2] ^ (mask & (a[2] ^ b[2]))
+	f[3] = b[3] ^ (mask & (a[3] ^ b[3]))
+	f[4] = b[4] ^ (mask & (a[4] ^ b[4]))
+	f[5] = b[5] ^ (mask & (a[5] ^ b[5]))
+	return f
+}
+
+// Add sets f = a + b
+func (f *Element) Add(a, b Element) *Element {
+	var carry uint64
+	z0, carry := bits.Add64(a[0], b[0], carry)
+	z1, carry := bits.Add64(a[1], b[1], carry)
+	z2, carry := bits.Add64(a[2], b[2], carry)
+	z3, carry := bits.Add64(a[3], b[3], carry)
+	z4, carry := bits.Add64(a[4], b[4], carry)
+	z5, carry := bits.Add64(a[5], b[5], carry)
+	f.reduce(z0, z1, z2, z3, z4, z5, carry)
+	return f
+}
+
+// reduce sets f = z - p if z >= p and f = z otherwise, for z < 2p
+// given as n limbs and a carry bit
+func (f *Element) reduce(z0, z1, z2, z3, z4, z5, carry uint64) {
+	var borrow uint64
+	d0, borrow := bits.Sub64(z0, modulus[0], borrow)
+	d1, borrow := bits.Sub64(z1, modulus[1], borrow)
+	d2, borrow := bits.Sub64(z2, modulus[2], borrow)
+	d3, borrow := bits.Sub64(z3, modulus[3], borrow)
+	d4, borrow := bits.Sub64(z4, modulus[4], borrow)
+	d5, borrow := bits.Sub64(z5, modulus[5], borrow)
+	// keep z if and only if z - p borrowed and there was no carry
+	mask := -(borrow &^ carry)
+	f[0] = d0 ^ (mask & (z0 ^ d0))
+	f[1] = d1 ^ (mask & (z1 ^ d1))
+	f[2] = d2 ^ (mask & (z2 ^ d2))
+	f[3] = d3 ^ (mask & (z3 ^ d3))
+	f[4] = d4 ^ (mask & (z4 ^ d4))
+	f[5] = d5 ^ (mask & (z5 ^ d5))
+}
+
+// Sub sets f = a - b
+func (f *Element) Sub(a, b Element) *Element {
+	var borrow uint64
+	z0, borrow := bits.Sub64(a[0], b[0], borrow)
+	z1, borrow := bits.Sub64(a[1], b[1], borrow)
+	z2, borrow := bits.Sub64(a[2], b[2], borrow)
+	z3, borrow := bits.Sub64(a[3], b[3], borrow)
+	z4, borrow := bits.Sub64(a[4], b[4], borrow)
+	z5, borrow := bits.Sub64(a[5], b[5], borrow)
+	// add p back if the subtraction borrowed
+	mask := -borrow
+	var carry uint64
+	f[0], carry = bits.Add64(z0, modulus[0]&mask, carry)
+	f[1], carry = bits.Add64(z1, modulus[1]&mask, carry)
+	f[2], carry = bits.Add64(z2, modulus[2]&mask, carry)
+	f[3], carry = bits.Add64(z3, modulus[3]&mask, carry)
+	f[4], carry = bits.Add64(z4, modulus[4]&mask, carry)
+	f[5], carry = bits.Add64(z5, modulus[5]&mask, carry)
+	return f
+}
+
+// Double sets f = 2a
+func (f *Element) Double(a Element) *Element {
+	return f.Add(a, a)
+}
+
+// Neg sets f = -a
+func (f *Element) Neg(a Element) *Element {
+	return f.Sub(Element{}, a)
+}
+
+// Mul sets f = a * b
+func (f *Element) Mul(a, b Element) *Element {
+	var t0, t1, t2, t3, t4, t5, t6, t7, c, m uint64
+
+	// t += a * b[0]
+	c, t0 = madd1(a[0], b[0], t0)
+	c, t1 = madd2(a[1], b[0], t1, c)
+	c, t2 = madd2(a[2], b[0], t2, c)
+	c, t3 = madd2(a[3], b[0], t3, c)
+	c, t4 = madd2(a[4], b[0], t4, c)
+	c, t5 = madd2(a[5], b[0], t5, c)
+	t6, t7 = bits.Add64(t6, c, 0)
+
+	// t = (t + m * p) / 2^64, where m makes the low limb vanish
+	m = t0 * inv
+	c, _ = madd1(m, modulus[0], t0)
+	c, t0 = madd2(m, modulus[1], t1, c)
+	c, t1 = madd2(m, modulus[2], t2, c)
+	c, t2 = madd2(m, modulus[3], t3, c)
+	c, t3 = madd2(m, modulus[4], t4, c)
+	c, t4 = madd2(m, modulus[5], t5, c)
+	t5, c = bits.Add64(t6, c, 0)
+	t6 = t7 + c
+
+	// t += a * b[1]
+	c, t0 = madd1(a[0], b[1], t0)
+	c, t1 = madd2(a[1], b[1], t1, c)
+	c, t2 = madd2(a[2], b[1], t2, c)
+	c, t3 = madd2(a[3], b[1], t3, c)
+	c, t4 = madd2(a[4], b[1], t4, c)
+	c, t5 = madd2(a[5], b[1], t5, c)
+	t6, t7 = bits.Add64(t6, c, 0)
+
+	// t = (t + m * p) / 2^64, where m makes the low limb vanish
+	m = t0 * inv
+	c, _ = madd1(m, modulus[0], t0)
+	c, t0 = madd2(m, modulus[1], t1, c)
+	c, t1 = madd2(m, modulus[2], t2, c)
+	c, t2 = madd2(m, modulus[3], t3, c)
+	c, t3 = madd2(m, modulus[4], t4, c)
+	c, t4 = madd2(m, modulus[5], t5, c)
+	t5, c = bits.Add64(t6, c, 0)
+	t6 = t7 + c
+
+	// t += a * b[2]
+	c, t0 = madd1(a[0], b[2], t0)
+	c, t1 = madd2(a[1], b[2], t1, c)
+	c, t2 = madd2(a[2], b[2], t2, c)
+	c, t3 = madd2(a[3], b[2], t3, c)
+	c, t4 = madd2(a[4], b[2], t4, c)
+	c, t5 = madd2(a[5], b[2], t5, c)
+	t6, t7 = bits.Add64(t6, c, 0)
+
+	// t = (t + m * p) / 2^64, where m makes the low limb vanish
+	m = t0 * inv
+	c, _ = madd1(m, modulus[0], t0)
+	c, t0 = madd2(m, modulus[1], t1, c)
+	c, t1 = madd2(m, modulus[2], t2, c)
+	c, t2 = madd2(m, modulus[3], t3, c)
+	c, t3 = madd2(m, modulus[4], t4, c)
+	c, t4 = madd2(m, modulus[5], t5, c)
+	t5, c = bits.Add64(t6, c, 0)
+	t6 = t7 + c
+
+	// t += a * b[3]
+	c, t0 = madd1(a[0], b[3], t0)
+	c, t1 = madd2(a[1], b[3], t1, c)
+	c, t2 = madd2(a[2], b[3], t2, c)
+	c, t3 = madd2(a[3], b[3], t3, c)
+	c, t4 = madd2(a[4], b[3], t4, c)
+	c, t5 = madd2(a[5], b[3], t5, c)
+	t6, t7 = bits.Add64(t6, c, 0)
+
+	// t = (t + m * p) / 2^64, where m makes the low limb vanish
+	m = t0 * inv
+	c, _ = madd1(m, modulus[0], t0)
+	c, t0 = madd2(m, modulus[1], t1, c)
+	c, t1 = madd2(m, modulus[2], t2, c)
+	c, t2 = madd2(m, modulus[3], t3, c)
+	c, t3 = madd2(m, modulus[4], t4, c)
+	c, t4 = madd2(m, modulus[5], t5, c)
+	t5, c = bits.Add64(t6, c, 0)
+	t6 = t7 + c
+
+	// t += a * b[4]
+	c, t0 = madd1(a[0], b[4], t0)
+	c, t1 = madd2(a[1], b[4], t1, c)
+	c, t2 = madd2(a[2], b[4], t2, c)
+	c, t3 = madd2(a[3], b[4], t3, c)
+	c, t4 = madd2(a[4], b[4], t4, c)
+	c, t5 = madd2(a[5], b[4], t5, c)
+	t6, t7 = bits.Add64(t6, c, 0)
+
+	// t = (t + m * p) / 2^64, where m makes the low limb vanish
+	m = t0 * inv
+	c, _ = madd1(m, modulus[0], t0)
+	c, t0 = madd2(m, modulus[1], t1, c)
+	c, t1 = madd2(m, modulus[2], t2, c)
+	c, t2 = madd2(m, modulus[3], t3, c)
+	c, t3 = madd2(m, modulus[4], t4, c)
+	c, t4 = madd2(m, modulus[5], t5, c)
+	t5, c = bits.Add64(t6, c, 0)
+	t6 = t7 + c
+
+	// t += a * b[5]
+	c, t0 = madd1(a[0], b[5], t0)
+	c, t1 = madd2(a[1], b[5], t1, c)
+	c, t2 = madd2(a[2], b[5], t2, c)
+	c, t3 = madd2(a[3], b[5], t3, c)
+	c, t4 = madd2(a[4], b[5], t4, c)
+	c, t5 = madd2(a[5], b[5], t5, c)
+	t6, t7 = bits.Add64(t6, c, 0)
+
+	// t = (t + m * p) / 2^64, where m makes the low limb vanish
+	m = t0 * inv
+	c, _ = madd1(m, modulus[0], t0)
+	c, t0 = madd2(m, modulus[1], t1, c)
+	c, t1 = madd2(m, modulus[2], t2, c)
+	c, t2 = madd2(m, modulus[3], t3, c)
+	c, t3 = madd2(m, modulus[4], t4, c)
+	c, t4 = madd2(m, modulus[5], t5, c)
+	t5, c = bits.Add64(t6, c, 0)
+	t6 = t7 + c
+
+	// t < 2p
+	f.reduce(t0, t1, t2, t3, t4, t5, t6)
+	return f
+}
+
+// Square sets f = a * a
+func (f *Element) Square(a Element) *Element {
+	return f.Mul(a, a)
+}
+
+// expByVarTime sets f = a^e. It is variable time in e, which must be public
+func (f *Element) expByVarTime(a Element, e [6]uint64) *Element {
+	res := rOne
+	for i := len(e) - 1; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			res.Square(res)
+			if (e[i]>>uint(j))&1 == 1 {
+				res.Mul(res, a)
+			}
+		}
+	}
+	*f = res
+	return f
+}
+
+// Invert sets f = 1/a, computed as a^(p-2). The inverse of zero is zero
+func (f *Element) Invert(a Element) *Element {
+	return f.expByVarTime(a, pMinus2)
+}
+
+// Sqrt sets f to a square root of a and returns 1 if a is a square.
+// Otherwise f is set to garbage and 0 is returned.
+// As p = 3 mod 4 the root is a^((p+1)/4)
+func (f *Element) Sqrt(a Element) uint64 {
+	var root, check Element
+	root.expByVarTime(a, sqrtExp)
+	check.Square(root)
+
+	*f = root
+	return ConstantTimeEq(check, a)
+}
+
+// BytesInto sets buf to the canonical little endian encoding of f
+func (f *Element) BytesInto(buf *[Bytes]byte) {
+	// Multiplying by one in normal form leaves Montgomery form
+	var t Element
+	t.Mul(*f, Element{1})
+	for i := range t {
+		for j := 0; j < 8; j++ {
+			buf[8*i+j] = byte(t[i] >> uint(8*j))
+		}
+	}
+}
+
+// IntoBytes returns the canonical little endian encoding of f
+func (f *Element) IntoBytes() []byte {
+	var buf [Bytes]byte
+	f.BytesInto(&buf)
+	return buf[:]
+}
+
+// load returns the little endian limbs of b
+func load(b []byte) Element {
+	var t Element
+	for i := range t {
+		for j := 0; j < 8; j++ {
+			t[i] |= uint64(b[8*i+j]) << uint(8*j)
+		}
+	}
+	return t
+}
+
+// SetBytes sets f to the canonical little endian encoding in buf.
+// Returns an error, leaving f unchanged, if the value is not less than p
+func (f *Element) SetBytes(buf *[Bytes]byte) (*Element, error) {
+	t := load(buf[:])
+
+	var borrow uint64
+	for i := range t {
+		_, borrow = bits.Sub64(t[i], modulus[i], borrow)
+	}
+	if borrow == 0 {
+		return f, errors.New("field element is not canonical")
+	}
+
+	return f.Mul(t, r2), nil
+}
+
+// FromBytes sets f to the little endian value in byt reduced mod p.
+// If byt is uniformly random, the bias of f is negligible
+func (f *Element) FromBytes(byt [2 * Bytes]byte) *Element {
+	// lo * R + hi * 2^384 * R, as Montgomery multiplication divides by R
+	var lo, hi Element
+	lo.Mul(load(byt[:Bytes]), r2)
+	hi.Mul(load(byt[Bytes:]), r3)
+	return f.Add(lo, hi)
+}
+
+// String returns the big endian hex encoding of f
+func (f *Element) String() string {
+	var buf [Bytes]byte
+	f.BytesInto(&buf)
+
+	// reverse bytes
+	for i, j := 0, len(buf)-1; i < j; i, j = i+1, j-1 {
+		buf[i], buf[j] = buf[j], buf[i]
+	}
+
+	return "0x" + hex.EncodeToString(buf[:])
+}
+
+// madd1 returns a * b + c
+func madd1(a, b, c uint64) (hi, lo uint64) {
+	var carry uint64
+	hi, lo = bits.Mul64(a, b)
+	lo, carry = bits.Add64(lo, c, 0)
+	hi += carry
+	return hi, lo
+}
+
+// madd2 returns a * b + c + d, which cannot overflow 128 bits
+func madd2(a, b, c, d uint64) (hi, lo uint64) {
+	var carry uint64
+	hi, lo = bits.Mul64(a, b)
+	c, carry = bits.Add64(c, d, 0)
+	hi += carry
+	lo, carry = bits.Add64(lo, c, 0)
+	hi += carry
+	return hi, lo
+}
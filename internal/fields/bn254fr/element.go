@@ -0,0 +1,374 @@
+// Code generated by internal/fieldgen. DO NOT EDIT.
+
+package bn254fr
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/bits"
+)
+
+// Limbs is the number of 64 bit limbs of an element
+const Limbs = 4
+
+// Bytes is the size of the encoding of an element
+const Bytes = 32
+
+// Element is an element of the field of integers modulo
+// p = 0x30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001
+// in Montgomery form, as little endian 64 bit limbs.
+// All operations run in constant time
+type Element [4]uint64
+
+// inv = -(p^{-1} mod 2^64) mod 2^64
+const inv uint64 = 0xc2e1f593efffffff
+
+var (
+	// modulus is p
+	modulus = Element{0x43e1f593f0000001, 0x2833e84879b97091, 0xb85045b68181585d, 0x30644e72e131a029}
+
+	// rOne = 2^256 mod p, the Montgomery form of one
+	rOne = Element{0xac96341c4ffffffb, 0x36fc76959f60cd29, 0x666ea36f7879462e, 0x0e0a77c19a07df2f}
+
+	// r2 = 2^512 mod p
+	r2 = Element{0x1bb8e645ae216da7, 0x53fe3ab1e35c59e3, 0x8c49833d53bb8085, 0x0216d0b17f4e44a5}
+
+	// r3 = 2^768 mod p
+	r3 = Element{0x5e94d8e1b4bf0040, 0x2a489cbe1cfbb6b8, 0x893cc664a19fcfed, 0x0cf8594b7fcc657c}
+
+	// pMinus2 = p - 2 is the exponent of inversion
+	pMinus2 = [4]uint64{0x43e1f593efffffff, 0x2833e84879b97091, 0xb85045b68181585d, 0x30644e72e131a029}
+
+	// sqrtExp = (t - 1) / 2, where p - 1 = 2^s * t with t odd
+	sqrtExp = [4]uint64{0xcdcb848a1f0fac9f, 0x0c0ac2e9419f4243, 0x098d014dc2822db4, 0x0000000183227397}
+
+	// rootOfUnity = g^t for the smallest non-residue g, a primitive 2^s-th root of unity
+	rootOfUnity = Element{0x636e735580d13d9c, 0xa22bf3742445ffd6, 0x56452ac01eb203d8, 0x1860ef942963f9e7}
+)
+
+// s is the 2-adicity of p - 1
+const s = 28
+
+// SetZero sets f = 0
+func (f *Element) SetZero() *Element {
+	*f = Element{}
+	return f
+}
+
+// SetOne sets f = 1
+func (f *Element) SetOne() *Element {
+	*f = rOne
+	return f
+}
+
+// Set sets f = a
+func (f *Element) Set(a Element) *Element {
+	*f = a
+	return f
+}
+
+// FromU64 sets f = a
+func (f *Element) FromU64(a uint64) *Element {
+	return f.Mul(Element{a}, r2)
+}
+
+// IsZero returns 1 if f = 0 and 0 otherwise
+func (f *Element) IsZero() uint64 {
+	return ConstantTimeEq(*f, Element{})
+}
+
+// ConstantTimeEq returns 1 if a = b and 0 otherwise
+func ConstantTimeEq(a, b Element) uint64 {
+	var d uint64
+	d |= a[0] ^ b[0]
+	d |= a[1] ^ b[1]
+	d |= a[2] ^ b[2]
+	d |= a[3] ^ b[3]
+	// d | -d has its top bit set if and only if d != 0
+	return ((d | -d) >> 63) ^ 1
+}
+
+// CondSel sets f to a if c = 1 or to b if c = 0
+func (f *Element) CondSel(a, b Element, c uint64) *Element {
+	mask := -c
+	f[0] = b[0] ^ (mask & (a[0] ^ b[0]))
+	f[1] = b[1] ^ (mask & (a[1] ^ b[1]))
+	f[2] = b[2] ^ (mask & (a[2] ^ b[2]))
+	f[3] = b[3] ^ (mask & (a[3] ^ b[3]))
+	return f
+}
+
+// Add sets f = a + b
+func (f *Element) Add(a, b Element) *Element {
+	var carry uint64
+	z0, carry := bits.Add64(a[0], b[0], carry)
+	z1, carry := bits.Add64(a[1], b[1], carry)
+	z2, carry := bits.Add64(a[2], b[2], carry)
+	z3, carry := bits.Add64(a[3], b[3], carry)
+	f.reduce(z0, z1, z2, z3, carry)
+	return f
+}
+
+// reduce sets f = z - p if z >= p and f = z otherwise, for z < 2p
+// given as n limbs and a carry bit
+func (f *Element) reduce(z0, z1, z2, z3, carry uint64) {
+	var borrow uint64
+	d0, borrow := bits.Sub64(z0, modulus[0], borrow)
+	d1, borrow := bits.Sub64(z1, modulus[1], borrow)
+	d2, borrow := bits.Sub64(z2, modulus[2], borrow)
+	d3, borrow := bits.Sub64(z3, modulus[3], borrow)
+	// keep z if and only if z - p borrowed and there was no carry
+	mask := -(borrow &^ carry)
+	f[0] = d0 ^ (mask & (z0 ^ d0))
+	f[1] = d1 ^ (mask & (z1 ^ d1))
+	f[2] = d2 ^ (mask & (z2 ^ d2))
+	f[3] = d3 ^ (mask & (z3 ^ d3))
+}
+
+// Sub sets f = a - b
+func (f *Element) Sub(a, b Element) *Element {
+	var borrow uint64
+	z0, borrow := bits.Sub64(a[0], b[0], borrow)
+	z1, borrow := bits.Sub64(a[1], b[1], borrow)
+	z2, borrow := bits.Sub64(a[2], b[2], borrow)
+	z3, borrow := bits.Sub64(a[3], b[3], borrow)
+	// add p back if the subtraction borrowed
+	mask := -borrow
+	var carry uint64
+	f[0], carry = bits.Add64(z0, modulus[0]&mask, carry)
+	f[1], carry = bits.Add64(z1, modulus[1]&mask, carry)
+	f[2], carry = bits.Add64(z2, modulus[2]&mask, carry)
+	f[3], carry = bits.Add64(z3, modulus[3]&mask, carry)
+	return f
+}
+
+// Double sets f = 2a
+func (f *Element) Double(a Element) *Element {
+	return f.Add(a, a)
+}
+
+// Neg sets f = -a
+func (f *Element) Neg(a Element) *Element {
+	return f.Sub(Element{}, a)
+}
+
+// Mul sets f = a * b
+func (f *Element) Mul(a, b Element) *Element {
+	var t0, t1, t2, t3, t4, t5, c, m uint64
+
+	// t += a * b[0]
+	c, t0 = madd1(a[0], b[0], t0)
+	c, t1 = madd2(a[1], b[0], t1, c)
+	c, t2 = madd2(a[2], b[0], t2, c)
+	c, t3 = madd2(a[3], b[0], t3, c)
+	t4, t5 = bits.Add64(t4, c, 0)
+
+	// t = (t + m * p) / 2^64, where m makes the low limb vanish
+	m = t0 * inv
+	c, _ = madd1(m, modulus[0], t0)
+	c, t0 = madd2(m, modulus[1], t1, c)
+	c, t1 = madd2(m, modulus[2], t2, c)
+	c, t2 = madd2(m, modulus[3], t3, c)
+	t3, c = bits.Add64(t4, c, 0)
+	t4 = t5 + c
+
+	// t += a * b[1]
+	c, t0 = madd1(a[0], b[1], t0)
+	c, t1 = madd2(a[1], b[1], t1, c)
+	c, t2 = madd2(a[2], b[1], t2, c)
+	c, t3 = madd2(a[3], b[1], t3, c)
+	t4, t5 = bits.Add64(t4, c, 0)
+
+	// t = (t + m * p) / 2^64, where m makes the low limb vanish
+	m = t0 * inv
+	c, _ = madd1(m, modulus[0], t0)
+	c, t0 = madd2(m, modulus[1], t1, c)
+	c, t1 = madd2(m, modulus[2], t2, c)
+	c, t2 = madd2(m, modulus[3], t3, c)
+	t3, c = bits.Add64(t4, c, 0)
+	t4 = t5 + c
+
+	// t += a * b[2]
+	c, t0 = madd1(a[0], b[2], t0)
+	c, t1 = madd2(a[1], b[2], t1, c)
+	c, t2 = madd2(a[2], b[2], t2, c)
+	c, t3 = madd2(a[3], b[2], t3, c)
+	t4, t5 = bits.Add64(t4, c, 0)
+
+	// t = (t + m * p) / 2^64, where m makes the low limb vanish
+	m = t0 * inv
+	c, _ = madd1(m, modulus[0], t0)
+	c, t0 = madd2(m, modulus[1], t1, c)
+	c, t1 = madd2(m, modulus[2], t2, c)
+	c, t2 = madd2(m, modulus[3], t3, c)
+	t3, c = bits.Add64(t4, c, 0)
+	t4 = t5 + c
+
+	// t += a * b[3]
+	c, t0 = madd1(a[0], b[3], t0)
+	c, t1 = madd2(a[1], b[3], t1, c)
+	c, t2 = madd2(a[2], b[3], t2, c)
+	c, t3 = madd2(a[3], b[3], t3, c)
+	t4, t5 = bits.Add64(t4, c, 0)
+
+	// t = (t + m * p) / 2^64, where m makes the low limb vanish
+	m = t0 * inv
+	c, _ = madd1(m, modulus[0], t0)
+	c, t0 = madd2(m, modulus[1], t1, c)
+	c, t1 = madd2(m, modulus[2], t2, c)
+	c, t2 = madd2(m, modulus[3], t3, c)
+	t3, c = bits.Add64(t4, c, 0)
+	t4 = t5 + c
+
+	// t < 2p
+	f.reduce(t0, t1, t2, t3, t4)
+	return f
+}
+
+// Square sets f = a * a
+func (f *Element) Square(a Element) *Element {
+	return f.Mul(a, a)
+}
+
+// expByVarTime sets f = a^e. It is variable time in e, which must be public
+func (f *Element) expByVarTime(a Element, e [4]uint64) *Element {
+	res := rOne
+	for i := len(e) - 1; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			res.Square(res)
+			if (e[i]>>uint(j))&1 == 1 {
+				res.Mul(res, a)
+			}
+		}
+	}
+	*f = res
+	return f
+}
+
+// Invert sets f = 1/a, computed as a^(p-2). The inverse of zero is zero
+func (f *Element) Invert(a Element) *Element {
+	return f.expByVarTime(a, pMinus2)
+}
+
+// Sqrt sets f to a square root of a and returns 1 if a is a square.
+// Otherwise f is set to garbage and 0 is returned.
+// Uses the constant time variant of Tonelli-Shanks
+func (f *Element) Sqrt(a Element) uint64 {
+	var z, t, b, c, tmp Element
+
+	// z = a^((t - 1) / 2)
+	z.expByVarTime(a, sqrtExp)
+
+	t.Square(z)
+	t.Mul(t, a) // a^t
+	z.Mul(z, a) // a^((t + 1) / 2)
+	b = t
+	c = rootOfUnity
+
+	for i := s; i >= 2; i-- {
+		for j := 1; j <= i-2; j++ {
+			b.Square(b)
+		}
+		e := ConstantTimeEq(b, rOne)
+
+		tmp.Mul(z, c)
+		z.CondSel(z, tmp, e)
+		c.Square(c)
+		tmp.Mul(t, c)
+		t.CondSel(t, tmp, e)
+		b = t
+	}
+
+	tmp.Square(z)
+	*f = z
+	return ConstantTimeEq(tmp, a)
+}
+
+// BytesInto sets buf to the canonical little endian encoding of f
+func (f *Element) BytesInto(buf *[Bytes]byte) {
+	// Multiplying by one in normal form leaves Montgomery form
+	var t Element
+	t.Mul(*f, Element{1})
+	for i := range t {
+		for j := 0; j < 8; j++ {
+			buf[8*i+j] = byte(t[i] >> uint(8*j))
+		}
+	}
+}
+
+// IntoBytes returns the canonical little endian encoding of f
+func (f *Element) IntoBytes() []byte {
+	var buf [Bytes]byte
+	f.BytesInto(&buf)
+	return buf[:]
+}
+
+// load returns the little endian limbs of b
+func load(b []byte) Element {
+	var t Element
+	for i := range t {
+		for j := 0; j < 8; j++ {
+			t[i] |= uint64(b[8*i+j]) << uint(8*j)
+		}
+	}
+	return t
+}
+
+// SetBytes sets f to the canonical little endian encoding in buf.
+// Returns an error, leaving f unchanged, if the value is not less than p
+func (f *Element) SetBytes(buf *[Bytes]byte) (*Element, error) {
+	t := load(buf[:])
+
+	var borrow uint64
+	for i := range t {
+		_, borrow = bits.Sub64(t[i], modulus[i], borrow)
+	}
+	if borrow == 0 {
+		return f, errors.New("field element is not canonical")
+	}
+
+	return f.Mul(t, r2), nil
+}
+
+// FromBytes sets f to the little endian value in byt reduced mod p.
+// If byt is uniformly random, the bias of f is negligible
+func (f *Element) FromBytes(byt [2 * Bytes]byte) *Element {
+	// lo * R + hi * 2^256 * R, as Montgomery multiplication divides by R
+	var lo, hi Element
+	lo.Mul(load(byt[:Bytes]), r2)
+	hi.Mul(load(byt[Bytes:]), r3)
+	return f.Add(lo, hi)
+}
+
+// String returns the big endian hex encoding of f
+func (f *Element) String() string {
+	var buf [Bytes]byte
+	f.BytesInto(&buf)
+
+	// reverse bytes
+	for i, j := 0, len(buf)-1; i < j; i, j = i+1, j-1 {
+		buf[i], buf[j] = buf[j], buf[i]
+	}
+
+	return "0x" + hex.EncodeToString(buf[:])
+}
+
+// madd1 returns a * b + c
+func madd1(a, b, c uint64) (hi, lo uint64) {
+	var carry uint64
+	hi, lo = bits.Mul64(a, b)
+	lo, carry = bits.Add64(lo, c, 0)
+	hi += carry
+	return hi, lo
+}
+
+// madd2 returns a * b + c + d, which cannot overflow 128 bits
+func madd2(a, b, c, d uint64) (hi, lo uint64) {
+	var carry uint64
+	hi, lo = bits.Mul64(a, b)
+	c, carry = bits.Add64(c, d, 0)
+	hi += carry
+	lo, carry = bits.Add64(lo, c, 0)
+	hi += carry
+	return hi, lo
+}
@@ -0,0 +1,9 @@
+// Package fields holds Montgomery fields generated by internal/fieldgen.
+// Regenerate them with go generate after changing the generator
+package fields
+
+// Baby Jubjub is defined over the scalar field of BN254
+//go:generate go run ../fieldgen -package bn254fr -out bn254fr -modulus 21888242871839275222246405745257275088548364400416034343698204186575808495617
+
+// The base field of BLS12-381
+//go:generate go run ../fieldgen -package bls12381fp -out bls12381fp -modulus 0x1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaab
@@ -2,40 +2,36 @@ package futil
 
 // util functions for field elements
 
-import "github.com/decentralisedkev/go-jubjub/internal/uint128"
+import "math/bits"
+
+// The helpers below are built on math/bits, which compiles to
+// branch-free carry instructions, so that field arithmetic
+// does not leak its operands through timing.
 
 // Adc Computes a + b + carry, returning the result and the new carry over.
 func Adc(a, b, carry uint64) (uint64, uint64) {
-	res := uint128.FromU64(a)
-	res = res.Add(b)
-	res = res.Add(carry)
+	res, c1 := bits.Add64(a, b, 0)
+	res, c2 := bits.Add64(res, carry, 0)
 
-	return res.L, res.H
+	return res, c1 + c2
 }
 
 // Sbb Computes a - (b + borrow), returning the result and the new borrow.
+// The borrow is either 0 or 0xfff...fff
 func Sbb(a, b, borrow uint64) (uint64, uint64) {
+	res, borrowOut := bits.Sub64(a, b, borrow>>63)
 
-	a128 := uint128.FromU64(a)
-	b128 := uint128.FromU64(b)
-	borr128 := uint128.FromU64(borrow >> 63)
-
-	bBor := b128.AddU128(borr128)
-	res := a128.SubU128(bBor)
-
-	return res.L, res.H
-
+	return res, -borrowOut
 }
 
 // Mac Computes a + (b * c) + carry, returning the result and the new carry over.
 func Mac(a, b, c, carry uint64) (uint64, uint64) {
+	hi, lo := bits.Mul64(b, c)
 
-	res := uint128.FromU64(b)
-	res = res.MulU64(c)
-	res = res.Add(a)
-	res = res.Add(carry)
+	lo, c1 := bits.Add64(lo, a, 0)
+	lo, c2 := bits.Add64(lo, carry, 0)
 
-	return res.L, res.H
+	return lo, hi + c1 + c2
 }
 
 // Load4 interprets a 4-byte unsigned little endian byte-slice as uint64
@@ -0,0 +1,326 @@
+package curve
+
+import "sync"
+
+// pippengerThreshold is the number of points above which
+// MultiScalarMulVarTime switches from Straus to Pippenger
+const pippengerThreshold = 190
+
+// MultiScalarMul sets e = sum(scalars[i] * points[i]), where every scalar
+// is the canonical little endian encoding of a scalar.
+//
+// Uses Straus' method: the doublings are shared between all of the points
+// and every point uses its own constant time window table, so it runs in
+// constant time with respect to the scalars. Pippenger's bucket method is
+// not used here, since the bucket a point is added to depends on the scalar
+func (e *ExtendedPoint) MultiScalarMul(points []ExtendedPoint, scalars [][32]byte) *ExtendedPoint {
+
+	tables := make([]nielsTable, len(points))
+	digits := make([][64]int8, len(points))
+	for i := range points {
+		tables[i].SetExtended(points[i])
+		digits[i] = radix16(scalars[i])
+	}
+
+	var res ExtendedPoint
+	res.SetZero()
+
+	var en ExtendedNielsPoint
+	for i := 63; i >= 0; i-- {
+		res.Double()
+		res.Double()
+		res.Double()
+		res.Double()
+
+		for j := range tables {
+			tables[j].Select(&en, digits[j][i])
+			res.AddExtendedNiels(&en)
+		}
+	}
+
+	*e = res
+
+	return e
+}
+
+// MultiScalarMulVarTime sets e = sum(scalars[i] * points[i]), where every
+// scalar is the canonical little endian encoding of a scalar.
+// It runs in variable time and must only be used with public scalars.
+// Uses Straus' method for a small number of points and Pippenger's
+// bucket method otherwise
+func (e *ExtendedPoint) MultiScalarMulVarTime(points []ExtendedPoint, scalars [][32]byte) *ExtendedPoint {
+	if len(points) < pippengerThreshold {
+		return e.strausVarTime(points, scalars)
+	}
+	return e.pippengerVarTime(points, scalars)
+}
+
+// strausVarTime is Straus' method, skipping the zero digits
+// and indexing the tables directly
+func (e *ExtendedPoint) strausVarTime(points []ExtendedPoint, scalars [][32]byte) *ExtendedPoint {
+
+	tables := make([]nielsTable, len(points))
+	digits := make([][64]int8, len(points))
+	for i := range points {
+		tables[i].SetExtended(points[i])
+		digits[i] = radix16(scalars[i])
+	}
+
+	var res ExtendedPoint
+	res.SetZero()
+
+	var en ExtendedNielsPoint
+	for i := 63; i >= 0; i-- {
+		res.Double()
+		res.Double()
+		res.Double()
+		res.Double()
+
+		for j := range tables {
+			digit := digits[j][i]
+			switch {
+			case digit > 0:
+				res.AddExtendedNiels(&tables[j][digit-1])
+			case digit < 0:
+				en = tables[j][-digit-1]
+				res.AddExtendedNiels(en.Neg())
+			}
+		}
+	}
+
+	*e = res
+
+	return e
+}
+
+// pippengerVarTime is Pippenger's bucket method.
+// Every scalar is split into signed digits of w bits, and for every
+// window the points are summed into 2^(w-1) buckets by digit, after
+// which the buckets are combined with a running sum
+func (e *ExtendedPoint) pippengerVarTime(points []ExtendedPoint, scalars [][32]byte) *ExtendedPoint {
+
+	w := pippengerWindow(len(points))
+
+	niels := make([]ExtendedNielsPoint, len(points))
+	digits := make([][]int32, len(points))
+	for i := range points {
+		niels[i].SetExtended(points[i])
+		digits[i] = signedRadix(scalars[i], w)
+	}
+
+	buckets := make([]ExtendedPoint, 1<<(w-1))
+
+	var res ExtendedPoint
+	res.SetZero()
+
+	var en ExtendedNielsPoint
+	for i := len(digits[0]) - 1; i >= 0; i-- {
+		for k := uint(0); k < w; k++ {
+			res.Double()
+		}
+
+		res.Add(res, bucketSum(buckets, niels, digits, i, &en))
+	}
+
+	*e = res
+
+	return e
+}
+
+// bucketSum returns sum(digits[j][i] * points[j]) by sorting the
+// points into buckets by digit and summing the buckets
+func bucketSum(buckets []ExtendedPoint, niels []ExtendedNielsPoint, digits [][]int32, i int, en *ExtendedNielsPoint) ExtendedPoint {
+	for b := range buckets {
+		buckets[b].SetZero()
+	}
+
+	for j := range niels {
+		digit := digits[j][i]
+		switch {
+		case digit > 0:
+			buckets[digit-1].AddExtendedNiels(&niels[j])
+		case digit < 0:
+			*en = niels[j]
+			buckets[-digit-1].AddExtendedNiels(en.Neg())
+		}
+	}
+
+	// sum(b * buckets[b-1]) = buckets[n-1] + (buckets[n-1] + buckets[n-2]) + ...
+	var running, sum ExtendedPoint
+	running.SetZero()
+	sum.SetZero()
+	for b := len(buckets) - 1; b >= 0; b-- {
+		running.Add(running, buckets[b])
+		sum.Add(sum, running)
+	}
+
+	return sum
+}
+
+// maxPippengerWindow bounds the window, and with it the 2^(w-1) buckets
+// kept by every worker
+const maxPippengerWindow = 16
+
+// pippengerWindow returns the window size in bits for n points. It
+// minimises the number of additions, about n + 2^w for each of the
+// 256/w + 1 windows, which grows the window roughly like ln(n)
+func pippengerWindow(n int) uint {
+	best, bestCost := uint(4), -1
+	for w := uint(4); w <= maxPippengerWindow; w++ {
+		windows := (256+int(w)-1)/int(w) + 1
+		cost := windows * (n + 1<<w)
+		if bestCost < 0 || cost < bestCost {
+			best, bestCost = w, cost
+		}
+	}
+	return best
+}
+
+// signedRadix converts a little endian scalar into signed digits
+// e[i] in [-2^(w-1), 2^(w-1)) s.t. scalar = sum(e[i] * 2^(w*i))
+func signedRadix(scalar [32]byte, w uint) []int32 {
+
+	n := (256 + int(w) - 1) / int(w)
+	e := make([]int32, n+1)
+
+	// Read w bits starting at bit i*w
+	bit := func(pos int) int32 {
+		if pos >= 256 {
+			return 0
+		}
+		return int32(scalar[pos/8]>>(uint(pos)%8)) & 1
+	}
+
+	radix := int32(1) << w
+	half := radix >> 1
+
+	var carry int32
+	for i := 0; i < n; i++ {
+		var digit int32
+		for k := int(w) - 1; k >= 0; k-- {
+			digit = digit<<1 | bit(i*int(w)+k)
+		}
+
+		digit += carry
+		carry = (digit + half) >> w
+		e[i] = digit - carry*radix
+	}
+	e[n] = carry
+
+	return e
+}
+
+// MultiScalarMulParallel is MultiScalarMul with the points split into
+// chunks that are multiplied on up to workers goroutines. The partial
+// sums are added in chunk order, so the result does not depend on scheduling
+func (e *ExtendedPoint) MultiScalarMulParallel(points []ExtendedPoint, scalars [][32]byte, workers int) *ExtendedPoint {
+	return e.sumChunks(points, scalars, workers, (*ExtendedPoint).MultiScalarMul)
+}
+
+// MultiScalarMulVarTimeParallel is MultiScalarMulVarTime spread over up to
+// workers goroutines. Straus' method splits the points into chunks, while
+// Pippenger's method computes the bucket sums of different windows
+// concurrently. Partial results are always combined in the same order,
+// so the result does not depend on scheduling
+func (e *ExtendedPoint) MultiScalarMulVarTimeParallel(points []ExtendedPoint, scalars [][32]byte, workers int) *ExtendedPoint {
+	if workers <= 1 {
+		return e.MultiScalarMulVarTime(points, scalars)
+	}
+	if len(points) < pippengerThreshold {
+		return e.sumChunks(points, scalars, workers, (*ExtendedPoint).strausVarTime)
+	}
+	return e.pippengerVarTimeParallel(points, scalars, workers)
+}
+
+// sumChunks splits the points into one chunk per worker, computes msm
+// of every chunk concurrently and sets e to the sum of the results
+func (e *ExtendedPoint) sumChunks(points []ExtendedPoint, scalars [][32]byte, workers int, msm func(*ExtendedPoint, []ExtendedPoint, [][32]byte) *ExtendedPoint) *ExtendedPoint {
+	if workers > len(points) {
+		workers = len(points)
+	}
+	if workers <= 1 {
+		return msm(e, points, scalars)
+	}
+
+	chunk := (len(points) + workers - 1) / workers
+	workers = (len(points) + chunk - 1) / chunk
+	partial := make([]ExtendedPoint, workers)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		lo, hi := w*chunk, (w+1)*chunk
+		if hi > len(points) {
+			hi = len(points)
+		}
+
+		wg.Add(1)
+		go func(w, lo, hi int) {
+			defer wg.Done()
+			msm(&partial[w], points[lo:hi], scalars[lo:hi])
+		}(w, lo, hi)
+	}
+	wg.Wait()
+
+	var res ExtendedPoint
+	res.SetZero()
+	for w := range partial {
+		res.Add(res, partial[w])
+	}
+
+	*e = res
+
+	return e
+}
+
+// pippengerVarTimeParallel is Pippenger's bucket method with the
+// windows distributed over up to workers goroutines. Every worker
+// owns its buckets, and the window sums are combined afterwards
+func (e *ExtendedPoint) pippengerVarTimeParallel(points []ExtendedPoint, scalars [][32]byte, workers int) *ExtendedPoint {
+
+	w := pippengerWindow(len(points))
+
+	niels := make([]ExtendedNielsPoint, len(points))
+	digits := make([][]int32, len(points))
+	for i := range points {
+		niels[i].SetExtended(points[i])
+		digits[i] = signedRadix(scalars[i], w)
+	}
+
+	windows := len(digits[0])
+	if workers > windows {
+		workers = windows
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	sums := make([]ExtendedPoint, windows)
+
+	var wg sync.WaitGroup
+	for k := 0; k < workers; k++ {
+		wg.Add(1)
+		go func(k int) {
+			defer wg.Done()
+
+			buckets := make([]ExtendedPoint, 1<<(w-1))
+			var en ExtendedNielsPoint
+			for i := k; i < windows; i += workers {
+				sums[i] = bucketSum(buckets, niels, digits, i, &en)
+			}
+		}(k)
+	}
+	wg.Wait()
+
+	var res ExtendedPoint
+	res.SetZero()
+	for i := windows - 1; i >= 0; i-- {
+		for k := uint(0); k < w; k++ {
+			res.Double()
+		}
+		res.Add(res, sums[i])
+	}
+
+	*e = res
+
+	return e
+}
@@ -0,0 +1,122 @@
+package curve
+
+// nielsTable holds the multiples [P, 2P, ..., 8P] of a point P
+type nielsTable [8]ExtendedNielsPoint
+
+// SetExtended fills the table with the multiples of p
+func (t *nielsTable) SetExtended(p ExtendedPoint) *nielsTable {
+	var acc ExtendedPoint
+	acc = p
+	t[0].SetExtended(acc)
+	for i := 1; i < len(t); i++ {
+		acc.AddExtendedNiels(&t[0])
+		t[i].SetExtended(acc)
+	}
+	return t
+}
+
+// Select sets en to digit * P for digit in [-8, 8].
+// Every entry of the table is read, so that the memory access
+// pattern does not depend on digit
+func (t *nielsTable) Select(en *ExtendedNielsPoint, digit int8) {
+	sign, abs := signAbs(digit)
+
+	en.Identity()
+	for j := range t {
+		en.ConditionalSelect(t[j], *en, ctEq(abs, uint64(j+1)))
+	}
+	en.ConditionalNegate(sign)
+}
+
+// AffineNielsTable holds the multiples [P, 2P, ..., 8P] of a point P
+// in affine Niels form. It is more expensive to build than an
+// ExtendedNielsPoint table, but cheaper to add from, so it
+// is used for points that are multiplied many times
+type AffineNielsTable [8]AffineNielsPoint
+
+// SetExtended fills the table with the multiples of p
+func (t *AffineNielsTable) SetExtended(p ExtendedPoint) *AffineNielsTable {
+	var acc ExtendedPoint
+	acc = p
+	t[0].SetExtended(acc)
+	for i := 1; i < len(t); i++ {
+		acc.AddAffineNiels(&t[0])
+		t[i].SetExtended(acc)
+	}
+	return t
+}
+
+// Select sets afn to digit * P for digit in [-8, 8].
+// Every entry of the table is read, so that the memory access
+// pattern does not depend on digit
+func (t *AffineNielsTable) Select(afn *AffineNielsPoint, digit int8) {
+	sign, abs := signAbs(digit)
+
+	afn.Identity()
+	for j := range t {
+		afn.ConditionalSelect(t[j], *afn, ctEq(abs, uint64(j+1)))
+	}
+	afn.ConditionalNegate(sign)
+}
+
+// MulScalarTable sets e = scalar * P, where table holds the multiples of P
+// and scalar is the canonical little endian encoding of a scalar
+func (e *ExtendedPoint) MulScalarTable(table *AffineNielsTable, scalar [32]byte) *ExtendedPoint {
+
+	digits := radix16(scalar)
+
+	var res ExtendedPoint
+	res.SetZero()
+
+	var afn AffineNielsPoint
+	for i := 63; i >= 0; i-- {
+		res.Double()
+		res.Double()
+		res.Double()
+		res.Double()
+
+		table.Select(&afn, digits[i])
+		res.AddAffineNiels(&afn)
+	}
+
+	*e = res
+
+	return e
+}
+
+// signAbs returns the sign bit and absolute value of digit without branching
+func signAbs(digit int8) (uint64, uint64) {
+	sign := uint64(uint8(digit) >> 7)
+	mask := digit >> 7
+	abs := uint64((digit ^ mask) - mask)
+	return sign, abs
+}
+
+// ctEq returns 1 if a == b and 0 otherwise, for a, b < 2^63
+func ctEq(a, b uint64) uint64 {
+	return ((a ^ b) - 1) >> 63
+}
+
+// radix16 converts a little endian scalar into 64 signed digits
+// e[i] in [-8, 8] s.t. scalar = sum(e[i] * 16^i).
+// The top bit of the scalar must be zero, which always holds for
+// canonical Jubjub scalars since r < 2^252
+func radix16(scalar [32]byte) [64]int8 {
+	var e [64]int8
+
+	for i, byt := range scalar {
+		e[2*i] = int8(byt & 15)
+		e[2*i+1] = int8((byt >> 4) & 15)
+	}
+
+	// Move each digit from [0, 16) into [-8, 8)
+	var carry int8
+	for i := 0; i < 63; i++ {
+		e[i] += carry
+		carry = (e[i] + 8) >> 4
+		e[i] -= carry << 4
+	}
+	e[63] += carry
+
+	return e
+}
@@ -0,0 +1,148 @@
+package curve
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRadix16(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		var scalar [32]byte
+		rand.Read(scalar[:])
+		scalar[31] &= 0x0f
+
+		digits := radix16(scalar)
+
+		// sum(e[i] * 16^i)
+		res := big.NewInt(0)
+		for j := 63; j >= 0; j-- {
+			assert.True(t, digits[j] >= -8 && digits[j] <= 8)
+			res.Lsh(res, 4)
+			res.Add(res, big.NewInt(int64(digits[j])))
+		}
+
+		// big.Int is big endian
+		for l, r := 0, len(scalar)-1; l < r; l, r = l+1, r-1 {
+			scalar[l], scalar[r] = scalar[r], scalar[l]
+		}
+		assert.Equal(t, new(big.Int).SetBytes(scalar[:]), res)
+	}
+}
+
+func TestSignedRadix(t *testing.T) {
+	for w := uint(4); w <= maxPippengerWindow; w++ {
+		var scalar [32]byte
+		rand.Read(scalar[:])
+
+		digits := signedRadix(scalar, w)
+
+		// sum(e[i] * 2^(w*i))
+		half := int32(1) << (w - 1)
+		res := big.NewInt(0)
+		for j := len(digits) - 1; j >= 0; j-- {
+			assert.True(t, digits[j] >= -half && digits[j] < half)
+			res.Lsh(res, w)
+			res.Add(res, big.NewInt(int64(digits[j])))
+		}
+
+		for l, r := 0, len(scalar)-1; l < r; l, r = l+1, r-1 {
+			scalar[l], scalar[r] = scalar[r], scalar[l]
+		}
+		assert.Equal(t, new(big.Int).SetBytes(scalar[:]), res, "w = %d", w)
+	}
+}
+
+func TestPippengerWindow(t *testing.T) {
+	assert.Equal(t, uint(6), pippengerWindow(pippengerThreshold))
+	assert.Equal(t, uint(13), pippengerWindow(1<<16))
+	assert.Equal(t, uint(16), pippengerWindow(1<<20))
+
+	// The window never shrinks as n grows
+	for n := pippengerThreshold; n < 1<<22; n *= 2 {
+		assert.True(t, pippengerWindow(n) <= pippengerWindow(2*n))
+	}
+}
+
+func TestTableSelect(t *testing.T) {
+	var p ExtendedPoint
+	var h [64]byte
+	h[0] = 7
+	p.FromBytes(h)
+
+	var table nielsTable
+	table.SetExtended(p)
+
+	var affineTable AffineNielsTable
+	affineTable.SetExtended(p)
+
+	var expected, negExpected ExtendedPoint
+	expected.SetZero()
+	for digit := int8(0); digit <= 8; digit++ {
+		negExpected.Neg(expected)
+
+		var en ExtendedNielsPoint
+		var afn AffineNielsPoint
+		var res ExtendedPoint
+
+		table.Select(&en, digit)
+		res.SetZero().AddExtendedNiels(&en)
+		assert.True(t, res.Equal(res, expected))
+
+		table.Select(&en, -digit)
+		res.SetZero().AddExtendedNiels(&en)
+		assert.True(t, res.Equal(res, negExpected))
+
+		affineTable.Select(&afn, digit)
+		res.SetZero().AddAffineNiels(&afn)
+		assert.True(t, res.Equal(res, expected))
+
+		affineTable.Select(&afn, -digit)
+		res.SetZero().AddAffineNiels(&afn)
+		assert.True(t, res.Equal(res, negExpected))
+
+		expected.Add(expected, p)
+	}
+}
+
+func TestMulScalarTable(t *testing.T) {
+	var p ExtendedPoint
+	var h [64]byte
+	h[0] = 9
+	p.FromBytes(h)
+
+	var table AffineNielsTable
+	table.SetExtended(p)
+
+	var scalar [32]byte
+	rand.Read(scalar[:])
+	scalar[31] &= 0x0f
+
+	var a, b ExtendedPoint
+	a.MulScalar(p, scalar)
+	b.MulScalarTable(&table, scalar)
+	assert.True(t, a.Equal(a, b))
+}
+
+func TestMulScalarComb(t *testing.T) {
+	var p ExtendedPoint
+	var h [64]byte
+	h[0] = 11
+	p.FromBytes(h)
+
+	var table CombTable
+	table.SetExtended(p)
+
+	for i := 0; i < 10; i++ {
+		var scalar [32]byte
+		rand.Read(scalar[:])
+		scalar[31] &= 0x0f
+
+		var a, b ExtendedPoint
+		a.MulScalar(p, scalar)
+		b.MulScalarComb(&table, scalar)
+		assert.True(t, a.Equal(a, b))
+	}
+}
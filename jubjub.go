@@ -2,6 +2,8 @@ package jubjub
 
 import (
 	"crypto/sha512"
+	"errors"
+	"sync"
 
 	curve "github.com/decentralisedkev/go-jubjub/internal"
 )
@@ -11,30 +13,67 @@ type Point curve.ExtendedPoint
 
 var basePoint = base()
 
+var (
+	basePrecomputedOnce sync.Once
+	basePrecomputed     *PrecomputedPoint
+)
+
+// basePointTable returns the precomputed base point used by
+// ScalarMultBase. It is built on first use
+func basePointTable() *PrecomputedPoint {
+	basePrecomputedOnce.Do(func() {
+		basePrecomputed = NewPrecomputedPoint((*Point)(basePoint))
+	})
+	return basePrecomputed
+}
+
+// generator is the encoding of the generator of the prime order subgroup
+// used by zkcrypto/jubjub, which is the point (u, 18) with u even:
+// u = 0x3fd2814c43ac65a6f1fbf02d0fd6cce62e3ebb21fd6c54ed4df7b7ffec7beaca
+var generator = [32]byte{18}
+
+// base returns the standard generator of the prime order subgroup
 func base() *curve.ExtendedPoint {
-	var p = &Point{}
-	p.HashToPoint([]byte("jubjub"))
+	var p Point
+	if _, err := p.SetBytes(generator[:]); err != nil {
+		panic(err)
+	}
 	return p.ep()
 }
 
+// ScalarMultBase multiplies the base point by the scalar s s.t. p = s * B.
+// Runs in constant time with respect to s
 func (p *Point) ScalarMultBase(s Scalar) *Point {
+	*p = *basePointTable().ScalarMult(s)
+	return p
+}
+
+// ScalarMult multiplies the point q by the scalar s s.t. p = s * q.
+// Runs in constant time with respect to s
+func (p *Point) ScalarMult(s Scalar, q *Point) *Point {
 	var buf [32]byte
 	s.BytesInto(&buf)
 
-	p.SetBase()
-	p.ep().MulScalar(*basePoint, buf)
+	p.ep().MulScalar(*q.ep(), buf)
 
 	return p
 }
 
+// HashToPoint maps d to a point using a variable time try-and-increment
+// method over SHA-512. The result is not cleared of its cofactor.
+//
+// Deprecated: use HashToCurve, which is constant time, domain separated
+// and returns a point in the prime order subgroup
 func (p *Point) HashToPoint(d []byte) *Point {
 	byt := sha512.Sum512(d)
 	p.ep().FromBytes(byt)
 	return p
 }
 
+// SetBase sets p to the base point, the standard generator
+// of the prime order subgroup
 func (p *Point) SetBase() *Point {
-	p.HashToPoint([]byte("jubjub"))
+	*p = Point(*basePoint)
 	return p
 }
 
@@ -42,6 +81,151 @@ func (p *Point) ep() *curve.ExtendedPoint {
 	return (*curve.ExtendedPoint)(p)
 }
 
+// Set sets p to the point `a`
+func (p *Point) Set(a Point) *Point {
+	*p = a
+	return p
+}
+
+// Identity sets p to the identity point
+func (p *Point) Identity() *Point {
+	p.ep().Identity()
+	return p
+}
+
+// IsIdentity returns true if p is the identity point
+func (p *Point) IsIdentity() bool {
+	return p.ep().IsIdentity()
+}
+
+// Add adds two points together s.t. p = a + b
+func (p *Point) Add(a, b Point) *Point {
+	p.ep().Add(curve.ExtendedPoint(a), curve.ExtendedPoint(b))
+	return p
+}
+
+// Sub subtracts two points s.t. p = a - b
+func (p *Point) Sub(a, b Point) *Point {
+	p.ep().Sub(curve.ExtendedPoint(a), curve.ExtendedPoint(b))
+	return p
+}
+
+// Neg returns the negation of a point s.t. p = -a
+func (p *Point) Neg(a Point) *Point {
+	p.ep().Neg(curve.ExtendedPoint(a))
+	return p
+}
+
+// Double doubles a point s.t. p = 2 * a
+func (p *Point) Double(a Point) *Point {
+	*p = a
+	p.ep().Double()
+	return p
+}
+
+// Equal returns true if p and q represent the same point
+func (p *Point) Equal(q Point) bool {
+	return p.ep().Equal(*p.ep(), curve.ExtendedPoint(q))
+}
+
+// IsSmallOrder returns true if p has order dividing the cofactor 8,
+// which includes the identity
+func (p *Point) IsSmallOrder() bool {
+	return p.ep().IsSmallOrder()
+}
+
+// IsTorsionFree returns true if p is in the prime order subgroup,
+// which includes the identity
+func (p *Point) IsTorsionFree() bool {
+	return p.ep().IsTorsionFree()
+}
+
+// IsPrimeOrder returns true if p has order r, i.e. it is
+// in the prime order subgroup and is not the identity
+func (p *Point) IsPrimeOrder() bool {
+	return !p.IsIdentity() && p.IsTorsionFree()
+}
+
+// ClearCofactor multiplies a by the cofactor s.t. p = 8 * a,
+// which is always in the prime order subgroup
+func (p *Point) ClearCofactor(a Point) *Point {
+	*p = a
+	p.ep().MulCof()
+	return p
+}
+
+// Bytes returns the canonical 32 byte encoding of p: the little endian
+// v-coordinate with the sign of the u-coordinate in the most significant bit
 func (p *Point) Bytes() []byte {
-	return  p.ep().
+	return p.ep().IntoBytes()
+}
+
+// UBytes returns the little endian encoding of the u-coordinate of p.
+// It is not an encoding of p in general, as (u, v) and (u, -v) share it,
+// but it is injective on the prime order subgroup
+func (p *Point) UBytes() []byte {
+	var af curve.AffinePoint
+	return af.SetExtended(p.ep()).UIntoBytes()
+}
+
+// BatchBytes returns the encodings of points, as produced by Bytes. It
+// shares one field inversion between all points, so it is much faster
+// than calling Bytes on each of them
+func BatchBytes(points []Point) [][]byte {
+	in := make([]curve.ExtendedPoint, len(points))
+	for i := range points {
+		in[i] = curve.ExtendedPoint(points[i])
+	}
+	out := make([]curve.AffinePoint, len(points))
+	curve.BatchSetExtended(out, in)
+
+	enc := make([][]byte, len(points))
+	for i := range out {
+		enc[i] = out[i].IntoBytes()
+	}
+	return enc
+}
+
+// SetBytes sets p to the point encoded in b, as produced by Bytes.
+// Returns an error if the encoding is not canonical or not on the curve
+func (p *Point) SetBytes(b []byte) (*Point, error) {
+	if len(b) != 32 {
+		return p, errors.New("point encoding must be 32 bytes")
+	}
+
+	var buf [32]byte
+	copy(buf[:], b)
+
+	if _, err := p.ep().SetBytes(buf); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// SetBytesSubgroup is SetBytes, but also returns an error if the point
+// is not in the prime order subgroup. It should be used to decode
+// untrusted points, such as public keys, to rule out small subgroup attacks.
+// The identity is in the subgroup, so it must be rejected separately if needed
+func (p *Point) SetBytesSubgroup(b []byte) (*Point, error) {
+	var q Point
+	if _, err := q.SetBytes(b); err != nil {
+		return p, err
+	}
+	if !q.IsTorsionFree() {
+		return p, errors.New("point is not in the prime order subgroup")
+	}
+
+	*p = q
+	return p, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (p *Point) MarshalBinary() ([]byte, error) {
+	return p.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (p *Point) UnmarshalBinary(data []byte) error {
+	_, err := p.SetBytes(data)
+	return err
 }
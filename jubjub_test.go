@@ -1,8 +1,11 @@
 package jubjub
 
 import (
+	"encoding/hex"
 	"fmt"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestHashToPoint(t *testing.T) {
@@ -12,3 +15,270 @@ func TestHashToPoint(t *testing.T) {
 
 	fmt.Println(hashed)
 }
+
+func TestPointIdentity(t *testing.T) {
+	var id, a, b Point
+	id.Identity()
+	assert.True(t, id.IsIdentity())
+
+	a.HashToPoint([]byte("a"))
+	assert.False(t, a.IsIdentity())
+
+	b.Add(a, id)
+	assert.True(t, b.Equal(a))
+
+	b.Sub(a, a)
+	assert.True(t, b.IsIdentity())
+
+	b.Add(a, *new(Point).Neg(a))
+	assert.True(t, b.IsIdentity())
+
+	b.Double(id)
+	assert.True(t, b.IsIdentity())
+}
+
+func TestPointGroupLaw(t *testing.T) {
+	var a, b, c Point
+	a.HashToPoint([]byte("a"))
+	b.HashToPoint([]byte("b"))
+	c.HashToPoint([]byte("c"))
+
+	assert.False(t, a.Equal(b))
+
+	// a + b = b + a
+	var ab, ba Point
+	ab.Add(a, b)
+	ba.Add(b, a)
+	assert.True(t, ab.Equal(ba))
+
+	// (a + b) + c = a + (b + c)
+	var lhs, rhs Point
+	lhs.Add(ab, c)
+	rhs.Add(b, c).Add(a, rhs)
+	assert.True(t, lhs.Equal(rhs))
+
+	// (a + b) - b = a
+	lhs.Sub(ab, b)
+	assert.True(t, lhs.Equal(a))
+
+	// 2a = a + a
+	lhs.Double(a)
+	rhs.Add(a, a)
+	assert.True(t, lhs.Equal(rhs))
+
+	// p.Add(p, p) is safe to alias
+	rhs.Set(a)
+	rhs.Add(rhs, rhs)
+	assert.True(t, lhs.Equal(rhs))
+}
+
+func TestPointBytesRoundTrip(t *testing.T) {
+	var p, q Point
+	for i := 0; i < 16; i++ {
+		p.HashToPoint([]byte{byte(i)})
+
+		_, err := q.SetBytes(p.Bytes())
+		assert.Nil(t, err)
+		assert.True(t, q.Equal(p))
+		assert.Equal(t, p.Bytes(), q.Bytes())
+
+		// The negation differs only in the sign bit
+		q.Neg(p)
+		enc, negEnc := p.Bytes(), q.Bytes()
+		assert.Equal(t, enc[:31], negEnc[:31])
+		assert.Equal(t, enc[31]^0x80, negEnc[31])
+	}
+
+	data, err := p.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Nil(t, q.UnmarshalBinary(data))
+	assert.True(t, q.Equal(p))
+}
+
+func TestBatchBytes(t *testing.T) {
+	assert.Empty(t, BatchBytes(nil))
+
+	points := make([]Point, 17)
+	points[0].Identity()
+	for i := 1; i < len(points); i++ {
+		points[i].HashToPoint([]byte{byte(i)})
+		// Points that are not normalised, z != 1
+		points[i].Add(points[i], points[i-1])
+	}
+
+	enc := BatchBytes(points)
+	assert.Len(t, enc, len(points))
+	for i := range points {
+		assert.Equal(t, points[i].Bytes(), enc[i])
+	}
+}
+
+func TestPointBytesIdentity(t *testing.T) {
+	var id, p Point
+	id.Identity()
+
+	enc := make([]byte, 32)
+	enc[0] = 1
+	assert.Equal(t, enc, id.Bytes())
+
+	_, err := p.SetBytes(enc)
+	assert.Nil(t, err)
+	assert.True(t, p.IsIdentity())
+}
+
+func TestPointSetBytesInvalid(t *testing.T) {
+	var p Point
+
+	// wrong length
+	_, err := p.SetBytes(make([]byte, 31))
+	assert.NotNil(t, err)
+
+	// v = 2 is not the v-coordinate of any point
+	enc := make([]byte, 32)
+	enc[0] = 2
+	_, err = p.SetBytes(enc)
+	assert.NotNil(t, err)
+
+	// v = q is not canonical
+	enc, _ = hex.DecodeString("01000000fffffffffe5bfeff02a4bd5305d8a10908d83933487d9d2953a7ed73")
+	_, err = p.SetBytes(enc)
+	assert.NotNil(t, err)
+
+	// ZIP 216: u = 0 must not have the sign bit set
+	enc = make([]byte, 32)
+	enc[0] = 1
+	enc[31] = 0x80
+	_, err = p.SetBytes(enc)
+	assert.NotNil(t, err)
+}
+
+func TestPointSetBytesGenerator(t *testing.T) {
+	// (u, 11) with u even is the Jubjub generator used by zkcrypto/jubjub
+	enc := make([]byte, 32)
+	enc[0] = 11
+
+	var p Point
+	_, err := p.SetBytes(enc)
+	assert.Nil(t, err)
+	assert.Equal(t, enc, p.Bytes())
+}
+
+func TestScalarMult(t *testing.T) {
+	var p Point
+	p.HashToPoint([]byte("p"))
+
+	var zero, one, s Scalar
+	zero.SetZero()
+	one.SetOne()
+
+	var res, expected Point
+	res.ScalarMult(zero, &p)
+	assert.True(t, res.IsIdentity())
+
+	// s * p computed by repeated addition
+	s.SetZero()
+	expected.Identity()
+	for i := 0; i < 40; i++ {
+		res.ScalarMult(s, &p)
+		assert.True(t, res.Equal(expected))
+
+		s.Add(s, one)
+		expected.Add(expected, p)
+	}
+}
+
+func TestScalarMultLinear(t *testing.T) {
+	var p Point
+	p.HashToPoint([]byte("p"))
+
+	// Scalars are reduced mod r, so p must have order r
+	p.Double(p).Double(p).Double(p)
+
+	var a, b, c Scalar
+	a.Rand()
+	b.Rand()
+	c.Add(a, b)
+
+	// (a + b) * p = a * p + b * p
+	var ap, bp, lhs, rhs Point
+	ap.ScalarMult(a, &p)
+	bp.ScalarMult(b, &p)
+	lhs.ScalarMult(c, &p)
+	rhs.Add(ap, bp)
+	assert.True(t, lhs.Equal(rhs))
+
+	// a * (b * p) = (a * b) * p
+	c.Mul(a, b)
+	lhs.ScalarMult(c, &p)
+	rhs.ScalarMult(a, &bp)
+	assert.True(t, lhs.Equal(rhs))
+
+	// p.ScalarMult(s, p) is safe to alias
+	lhs.ScalarMult(a, &p)
+	p.ScalarMult(a, &p)
+	assert.True(t, lhs.Equal(p))
+}
+
+func TestScalarMultOrder(t *testing.T) {
+	var p Point
+	p.HashToPoint([]byte("p"))
+
+	// Clear the cofactor so that p has order r
+	p.Double(p).Double(p).Double(p)
+
+	// (r - 1) * p = -p
+	var minusOne Scalar
+	minusOne.SetOne()
+	minusOne.Neg(minusOne)
+
+	var res, neg Point
+	res.ScalarMult(minusOne, &p)
+	neg.Neg(p)
+	assert.True(t, res.Equal(neg))
+}
+
+func TestScalarMultBase(t *testing.T) {
+	var s Scalar
+	s.Rand()
+
+	var base, lhs, rhs Point
+	base.SetBase()
+	lhs.ScalarMultBase(s)
+	rhs.ScalarMult(s, &base)
+	assert.True(t, lhs.Equal(rhs))
+}
+
+func BenchmarkScalarMult(b *testing.B) {
+	var s Scalar
+	s.Rand()
+
+	var p Point
+	p.HashToPoint([]byte("p"))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.ScalarMult(s, &p)
+	}
+}
+
+// BenchmarkScalarMultBase compares the comb table with the windowed
+// variable base multiplication that ScalarMultBase used before
+func BenchmarkScalarMultBase(b *testing.B) {
+	var s Scalar
+	s.Rand()
+
+	var B, p Point
+	B.SetBase()
+	p.ScalarMultBase(s)
+
+	b.Run("window", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.ScalarMult(s, &B)
+		}
+	})
+	b.Run("comb", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.ScalarMultBase(s)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package jubjub
+
+import (
+	"runtime"
+
+	curve "github.com/decentralisedkev/go-jubjub/internal"
+)
+
+// MSMOption configures a multi-scalar multiplication
+type MSMOption func(*msmConfig)
+
+type msmConfig struct {
+	workers int
+}
+
+// Parallel spreads a multi-scalar multiplication over up to n goroutines.
+// If n <= 0, GOMAXPROCS goroutines are used.
+// The result is the same as for the sequential computation
+func Parallel(n int) MSMOption {
+	return func(c *msmConfig) {
+		if n <= 0 {
+			n = runtime.GOMAXPROCS(0)
+		}
+		c.workers = n
+	}
+}
+
+func newMSMConfig(opts []MSMOption) msmConfig {
+	c := msmConfig{workers: 1}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// MultiScalarMult returns sum(scalars[i] * points[i]).
+// It runs in constant time with respect to the scalars, using Straus' method.
+// Panics if the number of scalars and points differ
+func MultiScalarMult(scalars []Scalar, points []Point, opts ...MSMOption) *Point {
+	eps, bufs := msmInputs(scalars, points)
+	c := newMSMConfig(opts)
+
+	var p Point
+	p.ep().MultiScalarMulParallel(eps, bufs, c.workers)
+	return &p
+}
+
+// MultiScalarMultVarTime returns sum(scalars[i] * points[i]).
+// It runs in variable time and must only be used with public scalars,
+// such as in batch verification. It uses Straus' method for a small
+// number of points and Pippenger's bucket method for a large number.
+// Panics if the number of scalars and points differ
+func MultiScalarMultVarTime(scalars []Scalar, points []Point, opts ...MSMOption) *Point {
+	eps, bufs := msmInputs(scalars, points)
+	c := newMSMConfig(opts)
+
+	var p Point
+	p.ep().MultiScalarMulVarTimeParallel(eps, bufs, c.workers)
+	return &p
+}
+
+func msmInputs(scalars []Scalar, points []Point) ([]curve.ExtendedPoint, [][32]byte) {
+	if len(scalars) != len(points) {
+		panic("jubjub: number of scalars and points differ")
+	}
+
+	eps := make([]curve.ExtendedPoint, len(points))
+	bufs := make([][32]byte, len(scalars))
+	for i := range points {
+		eps[i] = curve.ExtendedPoint(points[i])
+		scalars[i].BytesInto(&bufs[i])
+	}
+	return eps, bufs
+}
@@ -0,0 +1,126 @@
+package jubjub
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func randomMSMInputs(n int) ([]Scalar, []Point) {
+	scalars := make([]Scalar, n)
+	points := make([]Point, n)
+	for i := range points {
+		scalars[i].Rand()
+		points[i].HashToPoint([]byte(fmt.Sprintf("msm %d", i)))
+	}
+	return scalars, points
+}
+
+func naiveMSM(scalars []Scalar, points []Point) *Point {
+	var res, tmp Point
+	res.Identity()
+	for i := range points {
+		tmp.ScalarMult(scalars[i], &points[i])
+		res.Add(res, tmp)
+	}
+	return &res
+}
+
+func TestMultiScalarMult(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 7, 32} {
+		scalars, points := randomMSMInputs(n)
+		expected := naiveMSM(scalars, points)
+
+		assert.True(t, MultiScalarMult(scalars, points).Equal(*expected), "n = %d", n)
+		assert.True(t, MultiScalarMultVarTime(scalars, points).Equal(*expected), "n = %d", n)
+	}
+}
+
+func TestMultiScalarMultPippenger(t *testing.T) {
+	// Large enough to use Pippenger's method
+	scalars, points := randomMSMInputs(200)
+
+	// Include zero scalars and repeated points
+	scalars[3].SetZero()
+	points[5] = points[4]
+
+	expected := naiveMSM(scalars, points)
+	assert.True(t, MultiScalarMultVarTime(scalars, points).Equal(*expected))
+}
+
+func TestMultiScalarMultLengthMismatch(t *testing.T) {
+	scalars, points := randomMSMInputs(2)
+	assert.Panics(t, func() { MultiScalarMult(scalars[:1], points) })
+	assert.Panics(t, func() { MultiScalarMultVarTime(scalars, points[:1]) })
+}
+
+func BenchmarkMultiScalarMult(b *testing.B) {
+	for _, n := range []int{16, 64, 256, 1024} {
+		scalars, points := randomMSMInputs(n)
+
+		b.Run(fmt.Sprintf("%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				MultiScalarMult(scalars, points)
+			}
+		})
+		b.Run(fmt.Sprintf("VarTime/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				MultiScalarMultVarTime(scalars, points)
+			}
+		})
+	}
+}
+
+func TestMultiScalarMultParallel(t *testing.T) {
+	for _, n := range []int{1, 5, 200} {
+		scalars, points := randomMSMInputs(n)
+
+		expected := MultiScalarMult(scalars, points)
+		for _, workers := range []int{0, 2, 3, 64} {
+			res := MultiScalarMult(scalars, points, Parallel(workers))
+			assert.True(t, res.Equal(*expected), "n = %d, workers = %d", n, workers)
+		}
+
+		expected = MultiScalarMultVarTime(scalars, points)
+		for _, workers := range []int{0, 2, 3, 64} {
+			res := MultiScalarMultVarTime(scalars, points, Parallel(workers))
+			assert.True(t, res.Equal(*expected), "n = %d, workers = %d", n, workers)
+
+			// The merge order is fixed, so repeated runs give the same representation
+			again := MultiScalarMultVarTime(scalars, points, Parallel(workers))
+			assert.Equal(t, *res, *again)
+		}
+	}
+}
+
+func BenchmarkMultiScalarMultParallel(b *testing.B) {
+	for _, logN := range []uint{10, 12, 14, 16, 18, 20} {
+		n := 1 << logN
+
+		// Successive multiples of a point are much cheaper to
+		// generate than hashing every point
+		scalars := make([]Scalar, n)
+		points := make([]Point, n)
+		var g Point
+		g.HashToPoint([]byte("msm"))
+		points[0] = g
+		for i := range points {
+			scalars[i].Rand()
+			if i > 0 {
+				points[i].Add(points[i-1], g)
+			}
+		}
+
+		b.Run(fmt.Sprintf("2^%d/Sequential", logN), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				MultiScalarMultVarTime(scalars, points)
+			}
+		})
+		b.Run(fmt.Sprintf("2^%d/Parallel", logN), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				MultiScalarMultVarTime(scalars, points, Parallel(0))
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Package pedersen implements Pedersen commitments over the prime order
+// subgroup of Jubjub. A commitment to v with blinding factor r is
+// C = [v]G + [r]H, and a commitment to a vector v_0, ..., v_{n-1} is
+// C = [v_0]G_0 + ... + [v_{n-1}]G_{n-1} + [r]H. All generators are
+// derived with jubjub.HashToCurve, so nobody knows the discrete logarithm
+// of one with respect to another
+package pedersen
+
+import (
+	"encoding/binary"
+	"strconv"
+
+	jubjub "github.com/decentralisedkev/go-jubjub"
+)
+
+// DefaultDST is the domain separation tag of NewDefaultCommitter
+const DefaultDST = "JubjubPedersen-v1"
+
+// Messages hashed with the domain separation tag to derive the generators.
+// The vector generator G_i hashes vectorMsg || uint32 LE i
+var (
+	valueMsg  = []byte("value")
+	blindMsg  = []byte("blind")
+	vectorMsg = []byte("vector")
+)
+
+// Committer holds the generators of a commitment scheme. It is safe for
+// concurrent use
+type Committer struct {
+	// g is the value generator G
+	g jubjub.Point
+	// h is the blinding generator H
+	h jubjub.Point
+	// gens are the vector generators G_0, ..., G_{n-1}
+	gens []jubjub.Point
+}
+
+// NewCommitter derives the generators of a commitment scheme from dst,
+// supporting vectors of up to n values. Different tags give independent
+// schemes
+func NewCommitter(dst []byte, n int) *Committer {
+	if n < 0 {
+		panic("pedersen: negative vector length: " + strconv.Itoa(n))
+	}
+
+	c := &Committer{
+		g:    *jubjub.HashToCurve(valueMsg, dst),
+		h:    *jubjub.HashToCurve(blindMsg, dst),
+		gens: make([]jubjub.Point, n),
+	}
+
+	msg := make([]byte, len(vectorMsg)+4)
+	copy(msg, vectorMsg)
+	for i := range c.gens {
+		binary.LittleEndian.PutUint32(msg[len(vectorMsg):], uint32(i))
+		c.gens[i] = *jubjub.HashToCurve(msg, dst)
+	}
+	return c
+}
+
+// NewDefaultCommitter returns NewCommitter(DefaultDST, n)
+func NewDefaultCommitter(n int) *Committer {
+	return NewCommitter([]byte(DefaultDST), n)
+}
+
+// Len returns the largest vector length supported by c
+func (c *Committer) Len() int {
+	return len(c.gens)
+}
+
+// Commit returns the commitment [value]G + [blind]H.
+// Runs in constant time
+func (c *Committer) Commit(value, blind jubjub.Scalar) *jubjub.Point {
+	return jubjub.MultiScalarMult(
+		[]jubjub.Scalar{value, blind},
+		[]jubjub.Point{c.g, c.h},
+	)
+}
+
+// Open reports whether com is the commitment to value with blind
+func (c *Committer) Open(com *jubjub.Point, value, blind jubjub.Scalar) bool {
+	return com.Equal(*c.Commit(value, blind))
+}
+
+// CommitVector returns the commitment
+// [values_0]G_0 + ... + [values_{n-1}]G_{n-1} + [blind]H,
+// computed with a single multi-scalar multiplication.
+// Runs in constant time. Panics if len(values) > c.Len()
+func (c *Committer) CommitVector(values []jubjub.Scalar, blind jubjub.Scalar) *jubjub.Point {
+	n := len(values)
+	if n > len(c.gens) {
+		panic("pedersen: vector too long: " + strconv.Itoa(n))
+	}
+
+	scalars := make([]jubjub.Scalar, n+1)
+	copy(scalars, values)
+	scalars[n] = blind
+
+	points := make([]jubjub.Point, n+1)
+	copy(points, c.gens[:n])
+	points[n] = c.h
+
+	return jubjub.MultiScalarMult(scalars, points)
+}
+
+// OpenVector reports whether com is the commitment to values with blind.
+// Panics if len(values) > c.Len()
+func (c *Committer) OpenVector(com *jubjub.Point, values []jubjub.Scalar, blind jubjub.Scalar) bool {
+	return com.Equal(*c.CommitVector(values, blind))
+}
+
+// Add returns a + b, the commitment to the sum of the values of a and b
+// with the sum of their blinding factors
+func Add(a, b *jubjub.Point) *jubjub.Point {
+	var p jubjub.Point
+	return p.Add(*a, *b)
+}
+
+// Sub returns a - b, the commitment to the difference of the values of
+// a and b with the difference of their blinding factors
+func Sub(a, b *jubjub.Point) *jubjub.Point {
+	var p jubjub.Point
+	return p.Sub(*a, *b)
+}
@@ -0,0 +1,137 @@
+package pedersen
+
+import (
+	"encoding/hex"
+	"testing"
+
+	jubjub "github.com/decentralisedkev/go-jubjub"
+	"github.com/stretchr/testify/assert"
+)
+
+func scalar(v uint64) jubjub.Scalar {
+	var s jubjub.Scalar
+	s.SetUint64(v)
+	return s
+}
+
+func randomScalar() jubjub.Scalar {
+	var s jubjub.Scalar
+	s.Rand()
+	return s
+}
+
+// Vectors computed with an independent implementation of RFC 9380
+func TestVectors(t *testing.T) {
+	c := NewCommitter([]byte("JubjubPedersen-test"), 3)
+
+	assert.Equal(t, "f69c5ec7aabc5e51c2e92c07611387e6ff8c8dcb72c22b596901c8f4d17744ea", hex.EncodeToString(c.g.Bytes()))
+	assert.Equal(t, "2c7cd1b90dfcb5ba8c2a8f29bd464dde42ff77e929708f79d5449417ca63d70b", hex.EncodeToString(c.h.Bytes()))
+	assert.Equal(t, "c48f205714c5e40b5a53e471b442ba763699993beded6511dd71fc706b407eec", hex.EncodeToString(c.gens[2].Bytes()))
+
+	com := c.Commit(scalar(42), scalar(7))
+	assert.Equal(t, "51d4635be17d995ccf426278571dccc3feda572e9d3ebbf012c8352c78deae65", hex.EncodeToString(com.Bytes()))
+}
+
+func TestGenerators(t *testing.T) {
+	c := NewDefaultCommitter(8)
+	assert.Equal(t, 8, c.Len())
+
+	seen := map[string]bool{}
+	for _, p := range append([]jubjub.Point{c.g, c.h}, c.gens...) {
+		assert.False(t, p.IsIdentity())
+		seen[string(p.Bytes())] = true
+	}
+	assert.Len(t, seen, 10)
+
+	// Other tags give other generators, the same tag the same ones
+	other := NewCommitter([]byte("other"), 0)
+	assert.False(t, other.g.Equal(c.g))
+	assert.True(t, NewDefaultCommitter(1).gens[0].Equal(c.gens[0]))
+}
+
+func TestOpen(t *testing.T) {
+	c := NewDefaultCommitter(0)
+	v, r := randomScalar(), randomScalar()
+
+	com := c.Commit(v, r)
+	assert.True(t, c.Open(com, v, r))
+	assert.False(t, c.Open(com, r, v))
+	assert.False(t, c.Open(com, scalar(0), r))
+
+	var r1 jubjub.Scalar
+	r1.Add(r, scalar(1))
+	assert.False(t, c.Open(com, v, r1))
+}
+
+func TestHomomorphism(t *testing.T) {
+	c := NewDefaultCommitter(0)
+	v1, r1 := randomScalar(), randomScalar()
+	v2, r2 := randomScalar(), randomScalar()
+	c1, c2 := c.Commit(v1, r1), c.Commit(v2, r2)
+
+	var v, r jubjub.Scalar
+	v.Add(v1, v2)
+	r.Add(r1, r2)
+	assert.True(t, c.Open(Add(c1, c2), v, r))
+
+	v.Sub(v1, v2)
+	r.Sub(r1, r2)
+	assert.True(t, c.Open(Sub(c1, c2), v, r))
+
+	// The inputs are not modified
+	assert.True(t, c.Open(c1, v1, r1))
+	assert.True(t, c.Open(c2, v2, r2))
+}
+
+func TestCommitVector(t *testing.T) {
+	c := NewDefaultCommitter(5)
+
+	values := make([]jubjub.Scalar, 5)
+	for i := range values {
+		values[i] = randomScalar()
+	}
+	r := randomScalar()
+
+	for n := 0; n <= len(values); n++ {
+		com := c.CommitVector(values[:n], r)
+
+		// Compare with one scalar multiplication per generator
+		var want, p jubjub.Point
+		want.ScalarMult(r, &c.h)
+		for i := 0; i < n; i++ {
+			p.ScalarMult(values[i], &c.gens[i])
+			want.Add(want, p)
+		}
+		assert.True(t, com.Equal(want), "n = %d", n)
+		assert.True(t, c.OpenVector(com, values[:n], r))
+	}
+
+	// Vector commitments are additive element-wise
+	other := []jubjub.Scalar{randomScalar(), randomScalar()}
+	s := randomScalar()
+	sum := Add(c.CommitVector(values[:2], r), c.CommitVector(other, s))
+
+	var rs jubjub.Scalar
+	rs.Add(r, s)
+	summed := make([]jubjub.Scalar, 2)
+	for i := range summed {
+		summed[i].Add(values[i], other[i])
+	}
+	assert.True(t, c.OpenVector(sum, summed, rs))
+
+	assert.Panics(t, func() { c.CommitVector(make([]jubjub.Scalar, 6), r) })
+}
+
+func BenchmarkCommitVector(b *testing.B) {
+	c := NewDefaultCommitter(64)
+	values := make([]jubjub.Scalar, 64)
+	for i := range values {
+		values[i] = randomScalar()
+	}
+	r := randomScalar()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.CommitVector(values, r)
+	}
+}
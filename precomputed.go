@@ -0,0 +1,65 @@
+package jubjub
+
+import (
+	"errors"
+
+	curve "github.com/decentralisedkev/go-jubjub/internal"
+)
+
+// precomputedVersion is the first byte of an encoded PrecomputedPoint
+const precomputedVersion = 1
+
+// PrecomputedPoint holds a table of multiples of a fixed point, such as a
+// generator of a Pedersen commitment, for fast scalar multiplication.
+// The table takes 24KiB of memory and is slow to build, so it
+// should be built once per point and reused
+type PrecomputedPoint struct {
+	table curve.CombTable
+}
+
+// NewPrecomputedPoint builds the table of multiples of p
+func NewPrecomputedPoint(p *Point) *PrecomputedPoint {
+	var pp PrecomputedPoint
+	pp.table.SetExtended(*p.ep())
+	return &pp
+}
+
+// ScalarMult returns s * P, where P is the precomputed point.
+// Runs in constant time with respect to s
+func (pp *PrecomputedPoint) ScalarMult(s Scalar) *Point {
+	var buf [32]byte
+	s.BytesInto(&buf)
+
+	var p Point
+	p.ep().MulScalarComb(&pp.table, buf)
+	return &p
+}
+
+// Point returns the precomputed point
+func (pp *PrecomputedPoint) Point() *Point {
+	var one Scalar
+	one.SetOne()
+	return pp.ScalarMult(one)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// The encoding is a version byte followed by the affine coordinates
+// of every entry of the table, so that it can be loaded without
+// any inversions
+func (pp *PrecomputedPoint) MarshalBinary() ([]byte, error) {
+	return append([]byte{precomputedVersion}, pp.table.IntoBytes()...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// Returns an error if the table is not a valid table of multiples of a point
+func (pp *PrecomputedPoint) UnmarshalBinary(data []byte) error {
+	if len(data) != 1+curve.CombTableSize {
+		return errors.New("invalid precomputed point length")
+	}
+	if data[0] != precomputedVersion {
+		return errors.New("unknown precomputed point version")
+	}
+
+	_, err := pp.table.SetBytes(data[1:])
+	return err
+}
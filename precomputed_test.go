@@ -0,0 +1,87 @@
+package jubjub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrecomputedPointScalarMult(t *testing.T) {
+	var p Point
+	p.HashToPoint([]byte("generator"))
+
+	pp := NewPrecomputedPoint(&p)
+	assert.True(t, pp.Point().Equal(p))
+
+	var s Scalar
+	var expected Point
+	for i := 0; i < 10; i++ {
+		s.Rand()
+		expected.ScalarMult(s, &p)
+		assert.True(t, pp.ScalarMult(s).Equal(expected))
+	}
+}
+
+func TestPrecomputedPointMarshal(t *testing.T) {
+	var p Point
+	p.HashToPoint([]byte("generator"))
+	pp := NewPrecomputedPoint(&p)
+
+	data, err := pp.MarshalBinary()
+	assert.Nil(t, err)
+
+	var loaded PrecomputedPoint
+	assert.Nil(t, loaded.UnmarshalBinary(data))
+
+	var s Scalar
+	s.Rand()
+	assert.True(t, loaded.ScalarMult(s).Equal(*pp.ScalarMult(s)))
+}
+
+func TestPrecomputedPointUnmarshalInvalid(t *testing.T) {
+	var p Point
+	p.HashToPoint([]byte("generator"))
+	data, _ := NewPrecomputedPoint(&p).MarshalBinary()
+
+	var loaded PrecomputedPoint
+
+	// wrong length
+	assert.NotNil(t, loaded.UnmarshalBinary(data[:len(data)-1]))
+
+	// unknown version
+	tampered := append([]byte(nil), data...)
+	tampered[0] = 2
+	assert.NotNil(t, loaded.UnmarshalBinary(tampered))
+
+	// an entry that is not on the curve
+	tampered = append([]byte(nil), data...)
+	tampered[1+64*5] ^= 1
+	assert.NotNil(t, loaded.UnmarshalBinary(tampered))
+
+	// entries on the curve, but in the wrong order
+	tampered = append([]byte(nil), data...)
+	copy(tampered[1+64:1+128], data[1+128:1+192])
+	copy(tampered[1+128:1+192], data[1+64:1+128])
+	assert.NotNil(t, loaded.UnmarshalBinary(tampered))
+}
+
+func BenchmarkPrecomputedPointUnmarshal(b *testing.B) {
+	var p Point
+	p.HashToPoint([]byte("generator"))
+	data, _ := NewPrecomputedPoint(&p).MarshalBinary()
+
+	var loaded PrecomputedPoint
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		loaded.UnmarshalBinary(data)
+	}
+}
+
+func BenchmarkNewPrecomputedPoint(b *testing.B) {
+	var p Point
+	p.HashToPoint([]byte("generator"))
+
+	for i := 0; i < b.N; i++ {
+		NewPrecomputedPoint(&p)
+	}
+}
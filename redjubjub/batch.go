@@ -0,0 +1,34 @@
+package redjubjub
+
+import (
+	jubjub "github.com/decentralisedkev/go-jubjub"
+)
+
+// BatchVerifier verifies many signatures at once, which may mix spend
+// authorization and binding signatures. It accepts exactly the
+// signatures accepted by Verify
+type BatchVerifier struct {
+	bv *jubjub.BatchVerifier
+}
+
+// NewBatchVerifier returns an empty BatchVerifier
+func NewBatchVerifier() *BatchVerifier {
+	return &BatchVerifier{bv: jubjub.NewBatchVerifier()}
+}
+
+// Add queues the signature sig of msg by k. Signatures are indexed
+// in the order they are added
+func (v *BatchVerifier) Add(k *PublicKey, msg, sig []byte) {
+	R, s, c, ok := k.parse(msg, sig)
+	if !ok {
+		v.bv.AddInvalid()
+		return
+	}
+	v.bv.Add(k.sigType.basePoint(), &R, &k.point, s, c)
+}
+
+// Verify returns true if all queued signatures are valid, and -1.
+// Otherwise it returns false and the index of the first invalid signature
+func (v *BatchVerifier) Verify(opts ...jubjub.MSMOption) (bool, int) {
+	return v.bv.Verify(opts...)
+}
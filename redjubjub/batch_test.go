@@ -0,0 +1,72 @@
+package redjubjub
+
+import (
+	"crypto/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchVerifier(t *testing.T) {
+	const n = 24
+	keys := make([]*PublicKey, n)
+	msgs := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := range keys {
+		// Mix spend authorization and binding signatures
+		sk, err := GenerateKey(SigType(i%2), rand.Reader)
+		assert.Nil(t, err)
+		keys[i] = sk.Public()
+		msgs[i] = []byte("sighash " + strconv.Itoa(i))
+		sigs[i], err = sk.Sign(rand.Reader, msgs[i])
+		assert.Nil(t, err)
+	}
+
+	v := NewBatchVerifier()
+	for i := range keys {
+		v.Add(keys[i], msgs[i], sigs[i])
+	}
+	ok, idx := v.Verify()
+	assert.True(t, ok)
+	assert.Equal(t, -1, idx)
+
+	// Swap the message of signature 17
+	v = NewBatchVerifier()
+	for i := range keys {
+		msg := msgs[i]
+		if i == 17 {
+			msg = msgs[0]
+		}
+		v.Add(keys[i], msg, sigs[i])
+	}
+	ok, idx = v.Verify()
+	assert.False(t, ok)
+	assert.Equal(t, 17, idx)
+
+	// A signature checked against the key of the other type
+	v = NewBatchVerifier()
+	for i := range keys {
+		k := keys[i]
+		if i == 4 {
+			k, _ = NewPublicKey(1-k.sigType, k.Bytes())
+		}
+		v.Add(k, msgs[i], sigs[i])
+	}
+	ok, idx = v.Verify()
+	assert.False(t, ok)
+	assert.Equal(t, 4, idx)
+
+	// A malformed signature
+	v = NewBatchVerifier()
+	for i := range keys {
+		sig := sigs[i]
+		if i == 11 {
+			sig = sig[:32]
+		}
+		v.Add(keys[i], msgs[i], sig)
+	}
+	ok, idx = v.Verify()
+	assert.False(t, ok)
+	assert.Equal(t, 11, idx)
+}
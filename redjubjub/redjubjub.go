@@ -0,0 +1,279 @@
+// Package redjubjub implements RedJubjub, the instantiation of RedDSA
+// over Jubjub used by Sapling for spend authorization and binding
+// signatures, as specified in §5.4.7 of the Zcash protocol specification
+package redjubjub
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	jubjub "github.com/decentralisedkev/go-jubjub"
+	"github.com/decentralisedkev/go-jubjub/internal/blake2b"
+	"github.com/decentralisedkev/go-jubjub/sapling"
+)
+
+const (
+	// PrivateKeySize is the size of an encoded signing key in bytes
+	PrivateKeySize = 32
+	// PublicKeySize is the size of an encoded validating key in bytes
+	PublicKeySize = 32
+	// SignatureSize is the size of an encoded signature in bytes
+	SignatureSize = 64
+)
+
+// personalization of the BLAKE2b-512 hash H*
+var personalization = []byte("Zcash_RedJubjubH")
+
+// randomnessSize is the number of random bytes T hashed into each nonce,
+// (l_H + 128) / 8 with l_H = 512
+const randomnessSize = 80
+
+// SigType selects the base point of a RedJubjub instance
+type SigType int
+
+const (
+	// SpendAuth signatures use the spending key generator as base point
+	SpendAuth SigType = iota
+	// Binding signatures use the value commitment randomness generator
+	// as base point
+	Binding
+)
+
+var errUnknownSigType = errors.New("redjubjub: unknown signature type")
+
+func (t SigType) valid() bool {
+	return t == SpendAuth || t == Binding
+}
+
+// base returns the base point of t. The key constructors reject unknown
+// signature types, so the panic is unreachable
+func (t SigType) base() *jubjub.PrecomputedPoint {
+	switch t {
+	case SpendAuth:
+		return sapling.SpendingKeyGenerator()
+	case Binding:
+		return sapling.ValueCommitmentRandomnessGenerator()
+	}
+	panic("redjubjub: unknown signature type")
+}
+
+var basePoints [2]struct {
+	once sync.Once
+	p    jubjub.Point
+}
+
+// basePoint returns the base point of t, for the variable time
+// multi-scalar multiplications used in verification
+func (t SigType) basePoint() *jubjub.Point {
+	if !t.valid() {
+		panic("redjubjub: unknown signature type")
+	}
+
+	b := &basePoints[t]
+	b.once.Do(func() {
+		b.p = *t.base().Point()
+	})
+	return &b.p
+}
+
+// PrivateKey is a RedJubjub signing key
+type PrivateKey struct {
+	sigType SigType
+	sk      jubjub.Scalar
+	pub     PublicKey
+}
+
+// PublicKey is a RedJubjub validating key
+type PublicKey struct {
+	sigType SigType
+	point   jubjub.Point
+	bytes   [PublicKeySize]byte
+}
+
+// GenerateKey returns a new signing key of the given type,
+// using randomness from rand
+func GenerateKey(t SigType, rand io.Reader) (*PrivateKey, error) {
+	if !t.valid() {
+		return nil, errUnknownSigType
+	}
+
+	var buf [64]byte
+	if _, err := io.ReadFull(rand, buf[:]); err != nil {
+		return nil, err
+	}
+
+	var sk jubjub.Scalar
+	sk.FromBytes(buf)
+	return newPrivateKey(t, sk), nil
+}
+
+// NewPrivateKey decodes a signing key of the given type. It returns an
+// error if b is not the canonical encoding of a scalar
+func NewPrivateKey(t SigType, b []byte) (*PrivateKey, error) {
+	if !t.valid() {
+		return nil, errUnknownSigType
+	}
+
+	var sk jubjub.Scalar
+	if _, err := sk.SetCanonicalBytesSlice(b); err != nil {
+		return nil, err
+	}
+	return newPrivateKey(t, sk), nil
+}
+
+func newPrivateKey(t SigType, sk jubjub.Scalar) *PrivateKey {
+	k := &PrivateKey{sigType: t, sk: sk}
+	k.pub.sigType = t
+	k.pub.setPoint(*t.base().ScalarMult(sk))
+	return k
+}
+
+// Bytes returns the encoding of the signing key
+func (k *PrivateKey) Bytes() []byte {
+	var buf [PrivateKeySize]byte
+	k.sk.BytesInto(&buf)
+	return buf[:]
+}
+
+// Public returns the validating key of k
+func (k *PrivateKey) Public() *PublicKey {
+	pub := k.pub
+	return &pub
+}
+
+// Randomize returns the signing key sk + alpha, whose validating key is
+// the validating key of k randomized by the same alpha
+func (k *PrivateKey) Randomize(alpha jubjub.Scalar) *PrivateKey {
+	var sk jubjub.Scalar
+	sk.Add(k.sk, alpha)
+	return newPrivateKey(k.sigType, sk)
+}
+
+// Sign signs msg with k, using randomness from rand for the nonce
+func (k *PrivateKey) Sign(rand io.Reader, msg []byte) ([]byte, error) {
+	var t [randomnessSize]byte
+	if _, err := io.ReadFull(rand, t[:]); err != nil {
+		return nil, err
+	}
+
+	// r = H*(T || vk || M)
+	r := hStar(t[:], k.pub.bytes[:], msg)
+
+	// R = [r]P_G
+	R := k.sigType.base().ScalarMult(r).Bytes()
+
+	// S = r + H*(R || vk || M) * sk
+	c := hStar(R, k.pub.bytes[:], msg)
+	var s jubjub.Scalar
+	s.MulAdd(c, k.sk, r)
+
+	sig := make([]byte, SignatureSize)
+	copy(sig, R)
+	var sBytes [32]byte
+	s.BytesInto(&sBytes)
+	copy(sig[32:], sBytes[:])
+	return sig, nil
+}
+
+// NewPublicKey decodes a validating key of the given type. It returns
+// an error if b is not the canonical encoding of a point
+func NewPublicKey(t SigType, b []byte) (*PublicKey, error) {
+	if !t.valid() {
+		return nil, errUnknownSigType
+	}
+
+	var p jubjub.Point
+	if _, err := p.SetBytes(b); err != nil {
+		return nil, err
+	}
+
+	k := &PublicKey{sigType: t}
+	k.setPoint(p)
+	return k, nil
+}
+
+func (k *PublicKey) setPoint(p jubjub.Point) {
+	k.point = p
+	copy(k.bytes[:], p.Bytes())
+}
+
+// Bytes returns the encoding of the validating key
+func (k *PublicKey) Bytes() []byte {
+	b := k.bytes
+	return b[:]
+}
+
+// Equal returns true if k and o are the same validating key
+// of the same signature type
+func (k *PublicKey) Equal(o *PublicKey) bool {
+	return k.sigType == o.sigType && k.bytes == o.bytes
+}
+
+// Randomize returns the validating key vk + [alpha]P_G
+func (k *PublicKey) Randomize(alpha jubjub.Scalar) *PublicKey {
+	var p jubjub.Point
+	p.Add(k.point, *k.sigType.base().ScalarMult(alpha))
+
+	r := &PublicKey{sigType: k.sigType}
+	r.setPoint(p)
+	return r
+}
+
+// Verify reports whether sig is a valid signature of msg by k.
+// R and S must be canonically encoded, and the cofactored equation
+// [8](-[S]P_G + R + [c]vk) = O must hold
+func (k *PublicKey) Verify(msg, sig []byte) bool {
+	R, s, c, ok := k.parse(msg, sig)
+	if !ok {
+		return false
+	}
+
+	// All inputs are public, so variable time is fine
+	s.Neg(s)
+	check := jubjub.MultiScalarMultVarTime(
+		[]jubjub.Scalar{c, s},
+		[]jubjub.Point{k.point, *k.sigType.basePoint()},
+	)
+	check.Add(*check, R)
+	check.ClearCofactor(*check)
+
+	return check.IsIdentity()
+}
+
+// parse decodes the signature and computes the challenge
+// c = H*(R || vk || M). It returns false for signatures of the wrong
+// length, non-canonical encodings of R and S >= r
+func (k *PublicKey) parse(msg, sig []byte) (R jubjub.Point, s, c jubjub.Scalar, ok bool) {
+	if len(sig) != SignatureSize {
+		return
+	}
+	if _, err := R.SetBytes(sig[:32]); err != nil {
+		return
+	}
+	if _, err := s.SetCanonicalBytesSlice(sig[32:]); err != nil {
+		return
+	}
+
+	c = hStar(sig[:32], k.bytes[:], msg)
+	return R, s, c, true
+}
+
+// hStar is H*(M) = LEOS2IP(BLAKE2b-512("Zcash_RedJubjubH", M)) mod r,
+// where M is the concatenation of parts
+func hStar(parts ...[]byte) jubjub.Scalar {
+	h, err := blake2b.New(blake2b.Size, personalization)
+	if err != nil {
+		panic(err)
+	}
+	for _, p := range parts {
+		h.Write(p)
+	}
+
+	var wide [64]byte
+	copy(wide[:], h.Sum(nil))
+
+	var s jubjub.Scalar
+	s.SetUniformBytes(wide)
+	return s
+}
@@ -0,0 +1,204 @@
+package redjubjub
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	jubjub "github.com/decentralisedkev/go-jubjub"
+	"github.com/stretchr/testify/assert"
+)
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// T is fixed to the bytes 0, 1, ..., 79 so that signing is deterministic
+func fixedRandomness() *bytes.Reader {
+	t := make([]byte, randomnessSize)
+	for i := range t {
+		t[i] = byte(i)
+	}
+	return bytes.NewReader(t)
+}
+
+// Vectors computed with an independent implementation of §5.4.7
+// of the Zcash protocol specification
+var (
+	vectorSK    = "84e8f1ca9facb4de7bf79e000327113393c815c22ffc75b4852feff1a650f60b"
+	vectorAlpha = "d48ac5681ff28107419bc3bbfdf45208de3e2cd693b1a700fc974403be213d02"
+	vectorMsg   = []byte("Sapling spend")
+)
+
+func TestVectors(t *testing.T) {
+	tests := []struct {
+		name      string
+		sigType   SigType
+		randomize bool
+		vk        string
+		sig       string
+	}{
+		{"spend auth", SpendAuth, false,
+			"8031a508e32c092cbf99816fae6d1ad28483c4fe295ff8c17605afb161ffad09",
+			"2e0f01107bc06a91738c192ef02bde2c33e4c3ac4dcf9eadc2a6346ddced8e33332dd00fb2db0e96abf90a03fdd3b4c5f475bf4495bb172007f582d1b6ca5e04"},
+		{"binding", Binding, false,
+			"8a67683fb12398dd45dba55de5bb1a1a7d5792a04b8efb49c290f4383304e4ac",
+			"ffdac45b24bc7d39c0d8f4c3d727e895c857a0ec409b40fa3dcf1b4b9c1419322810e6def1e189530f9d592f8b685a39da377eab48da236c34cca62b77cfef0d"},
+		{"randomized spend auth", SpendAuth, true,
+			"299c1a055f7adef02c64bdbaaabed1bc47971171aad29241718c844ba4003bc1",
+			"90301cd672c10a0b176433458487008e600be348a2d3fb47352026fb1c93eb26799fed0aa5564003cd771758f8aa3f6294d83ba9d4bf93f7add4632d8d5ffc0c"},
+	}
+
+	for _, tt := range tests {
+		sk, err := NewPrivateKey(tt.sigType, mustHex(vectorSK))
+		assert.Nil(t, err, tt.name)
+		assert.Equal(t, vectorSK, hex.EncodeToString(sk.Bytes()), tt.name)
+
+		if tt.randomize {
+			var alpha jubjub.Scalar
+			_, err := alpha.SetCanonicalBytesSlice(mustHex(vectorAlpha))
+			assert.Nil(t, err)
+			sk = sk.Randomize(alpha)
+		}
+
+		assert.Equal(t, tt.vk, hex.EncodeToString(sk.Public().Bytes()), tt.name)
+
+		sig, err := sk.Sign(fixedRandomness(), vectorMsg)
+		assert.Nil(t, err, tt.name)
+		assert.Equal(t, tt.sig, hex.EncodeToString(sig), tt.name)
+
+		vk, err := NewPublicKey(tt.sigType, mustHex(tt.vk))
+		assert.Nil(t, err, tt.name)
+		assert.True(t, vk.Verify(vectorMsg, sig), tt.name)
+		assert.False(t, vk.Verify([]byte("Sapling spent"), sig), tt.name)
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	for _, sigType := range []SigType{SpendAuth, Binding} {
+		sk, err := GenerateKey(sigType, rand.Reader)
+		assert.Nil(t, err)
+		pk := sk.Public()
+
+		msg := []byte("message")
+		sig, err := sk.Sign(rand.Reader, msg)
+		assert.Nil(t, err)
+		assert.Equal(t, SignatureSize, len(sig))
+		assert.True(t, pk.Verify(msg, sig))
+
+		// Signatures do not carry over to the other base point
+		other, err := NewPublicKey(1-sigType, pk.Bytes())
+		assert.Nil(t, err)
+		assert.False(t, other.Verify(msg, sig))
+
+		// Flipping any bit invalidates the signature
+		for i := 0; i < SignatureSize; i += 7 {
+			bad := append([]byte(nil), sig...)
+			bad[i] ^= 1
+			assert.False(t, pk.Verify(msg, bad))
+		}
+		assert.False(t, pk.Verify(msg, sig[:63]))
+	}
+}
+
+func TestUnknownSigType(t *testing.T) {
+	sk, err := GenerateKey(SpendAuth, rand.Reader)
+	assert.Nil(t, err)
+
+	for _, sigType := range []SigType{-1, 2} {
+		_, err := GenerateKey(sigType, rand.Reader)
+		assert.Equal(t, errUnknownSigType, err)
+		_, err = NewPrivateKey(sigType, sk.Bytes())
+		assert.Equal(t, errUnknownSigType, err)
+		_, err = NewPublicKey(sigType, sk.Public().Bytes())
+		assert.Equal(t, errUnknownSigType, err)
+	}
+}
+
+func TestRandomize(t *testing.T) {
+	sk, err := GenerateKey(SpendAuth, rand.Reader)
+	assert.Nil(t, err)
+
+	var alpha jubjub.Scalar
+	alpha.Rand()
+
+	rsk := sk.Randomize(alpha)
+	rpk := sk.Public().Randomize(alpha)
+	assert.True(t, rsk.Public().Equal(rpk))
+	assert.False(t, rpk.Equal(sk.Public()))
+
+	msg := []byte("message")
+	sig, err := rsk.Sign(rand.Reader, msg)
+	assert.Nil(t, err)
+	assert.True(t, rpk.Verify(msg, sig))
+	assert.False(t, sk.Public().Verify(msg, sig))
+}
+
+func TestStrictEncodings(t *testing.T) {
+	sk, err := NewPrivateKey(SpendAuth, mustHex(vectorSK))
+	assert.Nil(t, err)
+	sig, err := sk.Sign(fixedRandomness(), vectorMsg)
+	assert.Nil(t, err)
+	pk := sk.Public()
+	assert.True(t, pk.Verify(vectorMsg, sig))
+
+	// S + r is rejected, although it satisfies the verification equation
+	r, _ := new(big.Int).SetString("0e7db4ea6533afa906673b0101343b00a6682093ccc81082d0970e5ed6f72cb7", 16)
+	s := new(big.Int).SetBytes(reverse(sig[32:]))
+	s.Add(s, r)
+	bad := append([]byte(nil), sig[:32]...)
+	bad = append(bad, reverse(leftPad(s.Bytes(), 32))...)
+	assert.False(t, pk.Verify(vectorMsg, bad))
+
+	// A non-canonical private key is rejected the same way
+	_, err = NewPrivateKey(SpendAuth, reverse(leftPad(r.Bytes(), 32)))
+	assert.NotNil(t, err)
+	_, err = NewPrivateKey(SpendAuth, make([]byte, 31))
+	assert.NotNil(t, err)
+
+	// v >= q is not a canonical point encoding
+	nonCanonical := bytes.Repeat([]byte{0xff}, 32)
+	nonCanonical[31] = 0x7f
+	_, err = NewPublicKey(SpendAuth, nonCanonical)
+	assert.NotNil(t, err)
+
+	bad = append(nonCanonical, sig[32:]...)
+	assert.False(t, pk.Verify(vectorMsg, bad))
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
+
+func leftPad(b []byte, n int) []byte {
+	return append(make([]byte, n-len(b)), b...)
+}
+
+func BenchmarkSign(b *testing.B) {
+	sk, _ := GenerateKey(SpendAuth, rand.Reader)
+	msg := []byte("message")
+	for i := 0; i < b.N; i++ {
+		sk.Sign(rand.Reader, msg)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	sk, _ := GenerateKey(SpendAuth, rand.Reader)
+	msg := []byte("message")
+	sig, _ := sk.Sign(rand.Reader, msg)
+	pk := sk.Public()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pk.Verify(msg, sig)
+	}
+}
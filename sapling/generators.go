@@ -0,0 +1,88 @@
+package sapling
+
+import (
+	"encoding/hex"
+	"sync"
+
+	jubjub "github.com/decentralisedkev/go-jubjub"
+)
+
+// Personalizations used to derive the Sapling generators with FindGroupHash
+const (
+	SpendingKeyPersonalization        = "Zcash_G_"
+	ProofGenerationKeyPersonalization = "Zcash_H_"
+	ValueCommitmentPersonalization    = "Zcash_cv"
+	NullifierPositionPersonalization  = "Zcash_J_"
+	PedersenHashPersonalization       = "Zcash_PH"
+)
+
+// generator is a fixed generator given by its encoding, whose table
+// of multiples is built on first use
+type generator struct {
+	encoding string
+
+	once sync.Once
+	pp   *jubjub.PrecomputedPoint
+}
+
+func (g *generator) get() *jubjub.PrecomputedPoint {
+	g.once.Do(func() {
+		enc, err := hex.DecodeString(g.encoding)
+		if err != nil {
+			panic(err)
+		}
+
+		var p jubjub.Point
+		if _, err := p.SetBytesSubgroup(enc); err != nil {
+			panic(err)
+		}
+		g.pp = jubjub.NewPrecomputedPoint(&p)
+	})
+	return g.pp
+}
+
+// The encodings are the outputs of FindGroupHash, which are checked in the tests
+var (
+	spendingKeyGenerator               = generator{encoding: "30b5f2aaad325630bcdddbce4d67656d05fd1cc2d037bb5375b6e96d9e01a1d7"}
+	proofGenerationKeyGenerator        = generator{encoding: "e7e85de0f7f97a46d249a1f5ea51df50cc48490f8401c9de7a2adf1807d1b6d4"}
+	valueCommitmentValueGenerator      = generator{encoding: "d7c86706f5817aa718cd1cfad03233bcd64a7789fd9422d3b17af6823a7e6ac6"}
+	valueCommitmentRandomnessGenerator = generator{encoding: "8b6a0b38b9faae3c3b803b47b0f146ad50ab221e6e2afbe6dbde45cba9d381ed"}
+	noteCommitmentRandomnessGenerator  = generator{encoding: "ac776c796563fcd44cc49cfaea8bb796952c266e47779d94574c10ad01754b11"}
+	nullifierPositionGenerator         = generator{encoding: "65002bc736faf7a3422effffe8b855e18fba96a0158a9efca584bf40549d36e1"}
+)
+
+// SpendingKeyGenerator returns the generator of spend authorization keys,
+// FindGroupHash("Zcash_G_", "")
+func SpendingKeyGenerator() *jubjub.PrecomputedPoint {
+	return spendingKeyGenerator.get()
+}
+
+// ProofGenerationKeyGenerator returns the generator of proof generation keys,
+// FindGroupHash("Zcash_H_", "")
+func ProofGenerationKeyGenerator() *jubjub.PrecomputedPoint {
+	return proofGenerationKeyGenerator.get()
+}
+
+// ValueCommitmentValueGenerator returns the value base of value commitments,
+// FindGroupHash("Zcash_cv", "v")
+func ValueCommitmentValueGenerator() *jubjub.PrecomputedPoint {
+	return valueCommitmentValueGenerator.get()
+}
+
+// ValueCommitmentRandomnessGenerator returns the randomness base of value
+// commitments, FindGroupHash("Zcash_cv", "r")
+func ValueCommitmentRandomnessGenerator() *jubjub.PrecomputedPoint {
+	return valueCommitmentRandomnessGenerator.get()
+}
+
+// NoteCommitmentRandomnessGenerator returns the randomness base of note
+// commitments, FindGroupHash("Zcash_PH", "r")
+func NoteCommitmentRandomnessGenerator() *jubjub.PrecomputedPoint {
+	return noteCommitmentRandomnessGenerator.get()
+}
+
+// NullifierPositionGenerator returns the generator used to mix the note
+// position into nullifiers, FindGroupHash("Zcash_J_", "")
+func NullifierPositionGenerator() *jubjub.PrecomputedPoint {
+	return nullifierPositionGenerator.get()
+}
@@ -0,0 +1,113 @@
+package sapling
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	jubjub "github.com/decentralisedkev/go-jubjub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratorsMatchGroupHash(t *testing.T) {
+	tests := []struct {
+		name                     string
+		generator                func() *jubjub.PrecomputedPoint
+		personalization, message string
+	}{
+		{"spending key", SpendingKeyGenerator, SpendingKeyPersonalization, ""},
+		{"proof generation key", ProofGenerationKeyGenerator, ProofGenerationKeyPersonalization, ""},
+		{"value commitment value", ValueCommitmentValueGenerator, ValueCommitmentPersonalization, "v"},
+		{"value commitment randomness", ValueCommitmentRandomnessGenerator, ValueCommitmentPersonalization, "r"},
+		{"note commitment randomness", NoteCommitmentRandomnessGenerator, PedersenHashPersonalization, "r"},
+		{"nullifier position", NullifierPositionGenerator, NullifierPositionPersonalization, ""},
+	}
+
+	for _, tc := range tests {
+		expected, err := FindGroupHash([]byte(tc.personalization), []byte(tc.message))
+		assert.Nil(t, err, tc.name)
+
+		g := tc.generator().Point()
+		assert.True(t, g.Equal(*expected), tc.name)
+		assert.True(t, g.IsPrimeOrder(), tc.name)
+	}
+}
+
+// encodeLimbs returns the encoding of the point with the affine
+// coordinates given as little endian 64 bit limbs: v with the sign of
+// u in the top bit
+func encodeLimbs(u, v [4]uint64) []byte {
+	buf := make([]byte, 32)
+	for i := range v {
+		binary.LittleEndian.PutUint64(buf[8*i:], v[i])
+	}
+	buf[31] |= byte(u[0]&1) << 7
+	return buf
+}
+
+// The generators from the constants of librustzcash, as the limbs of
+// their affine coordinates (u, v) in canonical form
+func TestGeneratorsPublished(t *testing.T) {
+	tests := []struct {
+		name      string
+		generator func() *jubjub.PrecomputedPoint
+		u, v      [4]uint64
+	}{
+		{
+			"SPENDING_KEY_GENERATOR", SpendingKeyGenerator,
+			[4]uint64{0x47bf46920a95a753, 0xd5b9a7d3ef8e2827, 0xd418a7ff26753b6a, 0x0926d4f32059c712},
+			[4]uint64{0x305632adaaf2b530, 0x6d65674dcedbddbc, 0x53bb37d0c21cfd05, 0x57a1019e6de9b675},
+		},
+		{
+			"PROOF_GENERATION_KEY_GENERATOR", ProofGenerationKeyGenerator,
+			[4]uint64{0x3af2dbefb96e2571, 0xadf2d038f2fbb820, 0x704303f1e8906081, 0x1457a50231cde2df},
+			[4]uint64{0x467af9f7e05de8e7, 0x50df51eaf5a149d2, 0xdec901840f4948cc, 0x54b6d10718df2a7a},
+		},
+		{
+			"VALUE_COMMITMENT_VALUE_GENERATOR", ValueCommitmentValueGenerator,
+			[4]uint64{0x36183b2cb4d7ef51, 0x9472c89ac043042d, 0xd8618ed1d15fef4e, 0x273f910d9ecc1615},
+			[4]uint64{0xa77a81f50667c8d7, 0xbc3332d0fa1ccd18, 0xd32294fd89774ad6, 0x466a7e3a82f67ab1},
+		},
+		{
+			"VALUE_COMMITMENT_RANDOMNESS_GENERATOR", ValueCommitmentRandomnessGenerator,
+			[4]uint64{0x3bce3b7793664337, 0xd1d8da41af03744e, 0x7ff6826ad58004b4, 0x6800f4fa0f001cfc},
+			[4]uint64{0x3caefab9380b6a8b, 0xad46f1b0473b803b, 0xe6fb2a6e1e22ab50, 0x6d81d3a9cb45dedb},
+		},
+		{
+			"NOTE_COMMITMENT_RANDOMNESS_GENERATOR", NoteCommitmentRandomnessGenerator,
+			[4]uint64{0xa5143b34a8e36462, 0xf0919d06ffb1ecda, 0xa1409aa1f33bec2c, 0x26eb9f8a9ec72a8c},
+			[4]uint64{0xd4fc6365796c77ac, 0x96b78beafa9cc44c, 0x949d77476e262c95, 0x114b7501ad104c57},
+		},
+		{
+			"NULLIFIER_POSITION_GENERATOR", NullifierPositionGenerator,
+			[4]uint64{0x2ce33921888d30db, 0xe81cee09a561229e, 0xdb56b6db8d8075ed, 0x2400c2e2e3362644},
+			[4]uint64{0xa3f7fa36c72b0065, 0xe155b8e8ffff2e42, 0xfc9e8a15a096ba8f, 0x61369d5440bf84a5},
+		},
+	}
+
+	for _, tc := range tests {
+		expected := encodeLimbs(tc.u, tc.v)
+		assert.Equal(t, hex.EncodeToString(expected), hex.EncodeToString(tc.generator().Point().Bytes()), tc.name)
+	}
+
+	// The spending key generator as a byte string, as an independent
+	// check of encodeLimbs
+	assert.Equal(t, "30b5f2aaad325630bcdddbce4d67656d05fd1cc2d037bb5375b6e96d9e01a1d7", hex.EncodeToString(SpendingKeyGenerator().Point().Bytes()))
+}
+
+func TestGroupHash(t *testing.T) {
+	_, err := GroupHash([]byte("short"), nil)
+	assert.NotNil(t, err)
+
+	// Not every message hashes to a point
+	var ok, failed int
+	for i := 0; i < 16; i++ {
+		if _, err := GroupHash([]byte(SpendingKeyPersonalization), []byte{byte(i)}); err == nil {
+			ok++
+		} else {
+			failed++
+		}
+	}
+	assert.True(t, ok > 0)
+	assert.True(t, failed > 0)
+}
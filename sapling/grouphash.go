@@ -0,0 +1,56 @@
+package sapling
+
+import (
+	"errors"
+
+	jubjub "github.com/decentralisedkev/go-jubjub"
+	"github.com/decentralisedkev/go-jubjub/internal/blake2s"
+)
+
+// urs is the uniform random string used as the first block of every
+// group hash, chosen as the hex of a Bitcoin block hash (§5.9 of the
+// Zcash protocol specification)
+var urs = []byte("096b36a5804bfacef1691e173c366a47ff5ba84a44f26ddd7e8d9f79d5b42df0")
+
+// GroupHash is GroupHash^J from §5.4.9.5 of the Zcash protocol
+// specification. It hashes msg into the prime order subgroup with the
+// 8 byte personalization, or returns an error if the hash is not the
+// encoding of a point or the point has small order
+func GroupHash(personalization, msg []byte) (*jubjub.Point, error) {
+	if len(personalization) != blake2s.PersonalSize {
+		return nil, errors.New("sapling: personalization must be 8 bytes")
+	}
+
+	h, err := blake2s.New(blake2s.Size, personalization)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(urs)
+	h.Write(msg)
+
+	var p jubjub.Point
+	if _, err := p.SetBytes(h.Sum(nil)); err != nil {
+		return nil, err
+	}
+
+	p.ClearCofactor(p)
+	if p.IsIdentity() {
+		return nil, errors.New("sapling: group hash is the identity")
+	}
+
+	return &p, nil
+}
+
+// FindGroupHash is FindGroupHash^J from §5.4.9.5 of the Zcash protocol
+// specification. It appends a counter byte to msg and returns the first
+// successful GroupHash
+func FindGroupHash(personalization, msg []byte) (*jubjub.Point, error) {
+	tag := append(append([]byte(nil), msg...), 0)
+	for i := 0; i < 256; i++ {
+		tag[len(tag)-1] = byte(i)
+		if p, err := GroupHash(personalization, tag); err == nil {
+			return p, nil
+		}
+	}
+	return nil, errors.New("sapling: no group hash found")
+}
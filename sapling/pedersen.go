@@ -0,0 +1,122 @@
+package sapling
+
+import (
+	"strconv"
+
+	jubjub "github.com/decentralisedkev/go-jubjub"
+)
+
+const (
+	// chunksPerSegment is the number of 3 bit chunks hashed with each
+	// generator, c in §5.4.1.7 of the Zcash protocol specification
+	chunksPerSegment = 63
+	bitsPerSegment   = 3 * chunksPerSegment
+
+	// personalizationBits is the size of a Personalization in bits
+	personalizationBits = 6
+
+	// merkleHashBits is the number of bits of each child of MerkleCRH
+	merkleHashBits = 255
+)
+
+// pedersenHashGenerators are FindGroupHash("Zcash_PH", I2LEOSP32(i)).
+// They are enough for inputs of up to 6 * 189 bits, as in Sapling.
+// The encodings are checked in the tests
+var pedersenHashGenerators = [...]generator{
+	{encoding: "ca3c2432d4abbf7732464ec08b2e47f95edc7e836b16c979571b52d3a2879ea8"},
+	{encoding: "9118bf4e3cc50d7be8d3fa98ebbe3a1f25d901c0421189f733fe435b7f8c5d01"},
+	{encoding: "57d493972c50ed8098b484177f2ab28b53e88c8e6ca400e09eee4ed200152eb6"},
+	{encoding: "e97035a3ec4b7184856a1fa1a1af0351b747d9d8cb0a0791d8ca564b0ce47e2f"},
+	{encoding: "ef8a65c3998296994cd1595809d8b9b3e5c90614383278390a9dab0321c54bc9"},
+	{encoding: "9a628d9f11826043a7136bc6d20002a8286a130a07b1cd64e5b6bfe88946ece4"},
+}
+
+// Personalization separates the uses of PedersenHash. Its 6 bits are
+// prepended to the input, least significant bit first
+type Personalization uint8
+
+// NoteCommitment is the personalization of note commitments
+const NoteCommitment Personalization = 0x3f
+
+// MerkleTree returns the personalization of the Merkle tree nodes whose
+// children are at the given depth, counted from the leaves at 0.
+// Panics if depth is not in [0, 62]
+func MerkleTree(depth int) Personalization {
+	if depth < 0 || depth >= int(NoteCommitment) {
+		panic("sapling: invalid Merkle tree depth: " + strconv.Itoa(depth))
+	}
+	return Personalization(depth)
+}
+
+// PedersenHash is PedersenHashToPoint from §5.4.1.7 of the Zcash
+// protocol specification, with the bits of personalization prepended
+// to bits. The input is split into segments of 63 chunks of 3 bits,
+// each chunk (s0, s1, s2) is encoded as (1 - 2*s2) * (1 + s0 + 2*s1) and
+// segment i is the scalar sum(enc(m_j) * 2^(4*j)) of the i-th generator.
+// Panics if there are more than 6 * 189 bits in total
+func PedersenHash(personalization Personalization, bits []bool) *jubjub.Point {
+	n := personalizationBits + len(bits)
+	if n > len(pedersenHashGenerators)*bitsPerSegment {
+		panic("sapling: Pedersen hash input too long: " + strconv.Itoa(len(bits)))
+	}
+
+	// bit returns the i-th bit of the personalized input, which is
+	// padded with zeros to a whole number of chunks
+	bit := func(i int) uint8 {
+		switch {
+		case i < personalizationBits:
+			return uint8(personalization>>uint(i)) & 1
+		case i < n && bits[i-personalizationBits]:
+			return 1
+		}
+		return 0
+	}
+
+	var p jubjub.Point
+	p.Identity()
+	for seg := 0; seg*bitsPerSegment < n; seg++ {
+		// As every chunk lies in its own nibble and |enc| <= 4, the
+		// positive and negative chunks can be laid out in the little
+		// endian encodings of two scalars without any carries. Both are
+		// less than 2^251, so they are canonical
+		var pos, neg [64]byte
+		for j := 0; j < chunksPerSegment; j++ {
+			i := seg*bitsPerSegment + 3*j
+			if i >= n {
+				break
+			}
+
+			s0, s1, s2 := bit(i), bit(i+1), bit(i+2)
+			mag := 1 + s0 + 2*s1
+			shift := 4 * uint(j&1)
+			pos[j/2] |= (mag * (1 - s2)) << shift
+			neg[j/2] |= (mag * s2) << shift
+		}
+
+		var s, sNeg jubjub.Scalar
+		s.FromBytes(pos)
+		sNeg.FromBytes(neg)
+		s.Sub(s, sNeg)
+
+		p.Add(p, *pedersenHashGenerators[seg].get().ScalarMult(s))
+	}
+	return &p
+}
+
+// MerkleCRH is MerkleCRH^Sapling from §5.4.1.3 of the Zcash protocol
+// specification: the u-coordinate of the Pedersen hash of the 255 low
+// bits of left and right, personalized with MerkleTree(depth). left,
+// right and the result are little endian encodings of u-coordinates.
+// Panics if depth is not in [0, 62]
+func MerkleCRH(depth int, left, right [32]byte) [32]byte {
+	bits := make([]bool, 0, 2*merkleHashBits)
+	for _, b := range [][32]byte{left, right} {
+		for i := 0; i < merkleHashBits; i++ {
+			bits = append(bits, b[i/8]>>uint(i%8)&1 == 1)
+		}
+	}
+
+	var out [32]byte
+	copy(out[:], PedersenHash(MerkleTree(depth), bits).UBytes())
+	return out
+}
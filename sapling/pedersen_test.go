@@ -0,0 +1,120 @@
+package sapling
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pattern returns n bits of the bytes 7, 36, 65, ..., least significant
+// bit first
+func pattern(n int) []bool {
+	bits := make([]bool, n)
+	for j := range bits {
+		b := byte((j/8)*29 + 7)
+		bits[j] = b>>uint(j%8)&1 == 1
+	}
+	return bits
+}
+
+func TestPedersenHashGenerators(t *testing.T) {
+	var msg [4]byte
+	for i := range pedersenHashGenerators {
+		binary.LittleEndian.PutUint32(msg[:], uint32(i))
+		expected, err := FindGroupHash([]byte(PedersenHashPersonalization), msg[:])
+		assert.Nil(t, err)
+
+		g := pedersenHashGenerators[i].get().Point()
+		assert.True(t, g.Equal(*expected), "generator %d", i)
+	}
+}
+
+// encodeHex returns the encoding of the point with the affine
+// coordinates u and v, given as big endian hexadecimal with a 0x prefix
+func encodeHex(u, v string) []byte {
+	bu, _ := new(big.Int).SetString(u[2:], 16)
+	bv, _ := new(big.Int).SetString(v[2:], 16)
+
+	buf := bv.FillBytes(make([]byte, 32))
+	for l, r := 0, len(buf)-1; l < r; l, r = l+1, r-1 {
+		buf[l], buf[r] = buf[r], buf[l]
+	}
+	buf[31] |= byte(bu.Bit(0)) << 7
+	return buf
+}
+
+// The first vector of the Pedersen hash test vectors of zcash_primitives
+// in zcash/librustzcash (sapling/pedersen_hash/test_vectors.rs), whose
+// input is only the personalization
+func TestPedersenHashPublished(t *testing.T) {
+	expected := encodeHex(
+		"0x06b1187c11ca4fb4383b2e0d0dbbde3ad3617338b5029187ec65a5eaed5e4d0b",
+		"0x3ce70f536652f0dea496393a1e55c4e08b9d55508e16d11e5db40d4810cbc982",
+	)
+	p := PedersenHash(NoteCommitment, nil)
+	assert.Equal(t, hex.EncodeToString(expected), hex.EncodeToString(p.Bytes()))
+}
+
+// Vectors printed by testdata/pedersen_hash.py, an implementation of
+// §5.4.1.7 of the Zcash protocol specification in Python. Their first
+// entry is the published vector above
+func TestPedersenHashVectors(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected string
+	}{
+		{0, "82c9cb10480db45d1ed1168e50559d8be0c4551e3a3996a4def05266530fe7bc"},
+		{1, "46e3977cdeeb0f60ca40fa74d0fb53fbff503cfee14f33afb9c9143bb95a2bb1"},
+		{3, "8204dc8f3e124340edfca26fc416ebedfb443b90b57cc7ad76d9c9d96b0acb1c"},
+		{5, "86d620f2d6e20e3b2f0557f75a0479eee1bfc21bc07682d637c596963d6ad4ae"},
+		// The first segment holds the personalization and 183 input bits
+		{189, "d8858846f21b5664e2389a16f3d70cd844a752cb67665264fb1e9bf6cbb1b48d"},
+		{190, "7503437de2955e2b6bbd6128fd2cdcfa64590f942d2b865fe1003cccb5b332d3"},
+		{600, "8abac921c6cf27f4e457465802701a5b3065d0a2daa3baad03e40515a674babb"},
+		// The longest input
+		{1128, "e00b55144c2f629c5b3a11340eb25d322647f46017cee0f090072b8b1278f624"},
+	}
+
+	for _, tc := range tests {
+		p := PedersenHash(NoteCommitment, pattern(tc.n))
+		assert.Equal(t, tc.expected, hex.EncodeToString(p.Bytes()), "n = %d", tc.n)
+	}
+
+	p := PedersenHash(MerkleTree(5), pattern(510))
+	assert.Equal(t, "d22a86b23ab08c83b97d6c249a5bc80b111e5e3ef299d9380cf1e3604e93d027", hex.EncodeToString(p.Bytes()))
+
+	assert.Panics(t, func() { PedersenHash(NoteCommitment, pattern(1129)) })
+}
+
+func TestMerkleTree(t *testing.T) {
+	assert.Equal(t, Personalization(0), MerkleTree(0))
+	assert.Equal(t, Personalization(62), MerkleTree(62))
+	assert.Panics(t, func() { MerkleTree(-1) })
+	assert.Panics(t, func() { MerkleTree(63) })
+}
+
+// The roots of the empty Sapling note commitment tree, whose leaves are
+// the uncommitted value 1, as published with the Zcash test vectors
+func TestMerkleCRHEmptyRoots(t *testing.T) {
+	roots := []string{
+		"817de36ab2d57feb077634bca77819c8e0bd298c04f6fed0e6a83cc1356ca155",
+		"ffe9fc03f18b176c998806439ff0bb8ad193afdb27b2ccbc88856916dd804e34",
+		"d8283386ef2ef07ebdbb4383c12a739a953a4d6e0d6fb1139a4036d693bfbb6c",
+	}
+
+	node := [32]byte{1}
+	for depth, root := range roots {
+		node = MerkleCRH(depth, node, node)
+		assert.Equal(t, root, hex.EncodeToString(node[:]), "depth %d", depth)
+	}
+}
+
+func BenchmarkMerkleCRH(b *testing.B) {
+	left, right := [32]byte{1}, [32]byte{2}
+	for i := 0; i < b.N; i++ {
+		MerkleCRH(0, left, right)
+	}
+}
@@ -0,0 +1,110 @@
+#!/usr/bin/env python3
+# Prints the Pedersen hash vectors of TestPedersenHashVectors.
+#
+# An implementation of PedersenHashToPoint from section 5.4.1.7 of the
+# Zcash protocol specification, written from the specification and
+# independent of the Go code. It needs Python 3.8 or later and only the
+# standard library.
+
+import hashlib
+
+q = 0x73EDA753299D7D483339D80809A1D80553BDA402FFFE5BFEFFFFFFFF00000001
+r = 0x0E7DB4EA6533AFA906673B0101343B00A6682093CCC81082D0970E5ED6F72CB7
+d = -10240 * pow(10241, -1, q) % q
+URS = b"096b36a5804bfacef1691e173c366a47ff5ba84a44f26ddd7e8d9f79d5b42df0"
+
+
+def sqrt(x):
+    # Tonelli-Shanks, q - 1 = 2^32 * t
+    if pow(x, (q - 1) // 2, q) != 1:
+        return None
+    s, t = 32, (q - 1) >> 32
+    z = 7
+    c, res, tt, m = pow(z, t, q), pow(x, (t + 1) // 2, q), pow(x, t, q), s
+    while tt != 1:
+        i, t2 = 1, tt * tt % q
+        while t2 != 1:
+            t2, i = t2 * t2 % q, i + 1
+        b = pow(c, 1 << (m - i - 1), q)
+        res, c, tt, m = res * b % q, b * b % q, tt * b * b % q, i
+    return res
+
+
+def add(p1, p2):
+    (u1, v1), (u2, v2) = p1, p2
+    e = d * u1 * u2 * v1 * v2 % q
+    u = (u1 * v2 + v1 * u2) * pow(1 + e, -1, q) % q
+    v = (v1 * v2 + u1 * u2) * pow(1 - e, -1, q) % q
+    return u, v
+
+
+def mul(k, p):
+    acc = (0, 1)
+    while k:
+        if k & 1:
+            acc = add(acc, p)
+        p, k = add(p, p), k >> 1
+    return acc
+
+
+def encode(p):
+    u, v = p
+    return (v | (u & 1) << 255).to_bytes(32, "little")
+
+
+def decode(b):
+    y = int.from_bytes(b, "little")
+    v, sign = y & ((1 << 255) - 1), y >> 255
+    if v >= q:
+        return None
+    u = sqrt((v * v - 1) * pow(d * v * v + 1, -1, q) % q)
+    if u is None or (u == 0 and sign):
+        return None
+    if u & 1 != sign:
+        u = q - u
+    return u, v
+
+
+def group_hash(pers, msg):
+    h = hashlib.blake2s(URS + msg, digest_size=32, person=pers).digest()
+    p = decode(h)
+    if p is None:
+        return None
+    p = mul(8, p)
+    return None if p == (0, 1) else p
+
+
+def find_group_hash(pers, msg):
+    for i in range(256):
+        p = group_hash(pers, msg + bytes([i]))
+        if p is not None:
+            return p
+    raise ValueError("no point found")
+
+
+def pedersen_hash(bits):
+    bits = bits + [0] * (-len(bits) % 3)
+    acc = (0, 1)
+    for seg in range(0, len(bits), 189):
+        chunks = bits[seg:seg + 189]
+        k = 0
+        for j in range(0, len(chunks), 3):
+            s0, s1, s2 = chunks[j:j + 3]
+            k += (1 - 2 * s2) * (1 + s0 + 2 * s1) << (4 * (j // 3))
+        g = find_group_hash(b"Zcash_PH", (seg // 189).to_bytes(4, "little"))
+        acc = add(acc, mul(k % r, g))
+    return acc
+
+
+def bits_le(x, n):
+    return [(x >> i) & 1 for i in range(n)]
+
+
+def pattern(n):
+    # The bits of the bytes 7, 36, 65, ..., least significant bit first
+    return [((j // 8 * 29 + 7) & 0xFF) >> (j % 8) & 1 for j in range(n)]
+
+
+for n in (0, 1, 3, 5, 189, 190, 600, 1128):
+    print(n, encode(pedersen_hash(bits_le(0x3F, 6) + pattern(n))).hex())
+print("MerkleTree(5)", encode(pedersen_hash(bits_le(5, 6) + pattern(510))).hex())
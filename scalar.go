@@ -3,43 +3,31 @@ package jubjub
 import (
 	"crypto/rand"
 	"crypto/sha512"
-	"encoding/hex"
+	"errors"
 	"math/big"
 
+	fr "github.com/decentralisedkev/go-jubjub/internal/Fr"
 	"github.com/decentralisedkev/go-jubjub/internal/field"
-	"github.com/decentralisedkev/go-jubjub/internal/futil"
 )
 
 // Scalar represents the scalar field of Jubjub
 type Scalar struct {
-	field.Field
+	fr.FieldR
 }
 
 // INV = -(r^{-1} mod 2^64) mod 2^64
-const INV uint64 = 0x1ba3a358ef788ef9
+//
+// Deprecated: the constants of the scalar field are internal
+const INV uint64 = fr.INV
 
-var (
-	// Note: r is the modulus and R is the R related to the montgomery reduce; the mont modulus
-	// R = 2^256 mod r
-	// the montgomoery modulus
-	montR = field.Field{0x25f80bb3b99607d9, 0xf315d62f66b6e750, 0x932514eeeb8814f4, 0x09a6fc6f479155c6}
-
-	// R2 (Rsquared) = 2^512 mod r
-	montR2 = field.Field{0x67719aa495e57731, 0x51b0cef09ce3fc26, 0x69dab7fac026e9a5, 0x04f6547b8d127688}
-
-	// R3 (RCubed) = 2^768 mod r
-	montR3 = field.Field{0xe0d6c6563d830544, 0x323e3883598d0f85, 0xf0fea3004c2e2ba8, 0x05874f84946737ec}
-
-	// r is modulus in the scalar
-	// r = 0x0e7db4ea6533afa906673b0101343b00a6682093ccc81082d0970e5ed6f72cb7
-	rMod = field.Field{0xd0970e5ed6f72cb7, 0xa6682093ccc81082, 0x06673b0101343b00, 0x0e7db4ea6533afa9}
-
-	// NEG1 = -R = -(2^256 mod r) mod r
-	NEG1 = field.Field{0xaa9f02ab1d6124de, 0xb3524a6466112932, 0x7342261215ac260b, 0x4d6b87b1da259e2}
-)
+// NEG1 = -R = -(2^256 mod r) mod r
+//
+// Deprecated: the constants of the scalar field are internal
+var NEG1 = fr.NEG1
 
+// BytesInto sets buf to the canonical little endian encoding of s
 func (s *Scalar) BytesInto(buf *[32]byte) {
-	s.Field.BytesInto(buf, rMod, INV)
+	s.FieldR.BytesInto(buf)
 }
 
 // Rand returns a random scalar in the Scalar field
@@ -50,35 +38,30 @@ func (s *Scalar) Rand() *Scalar {
 	return s
 }
 
+// FromBytes sets s to the 512 bit little endian value in byt reduced mod r
 func (s *Scalar) FromBytes(byt [64]byte) *Scalar {
-	s.Field.FromBytes(byt, INV, rMod, montR2, montR3)
+	s.FieldR.FromBytes(byt)
 	return s
 }
 
 // String returns the string representation of s
 func (s *Scalar) String() string {
-	var buf [32]byte
-	s.BytesInto(&buf)
-
-	// reverse bytes
-	for i, j := 0, len(buf)-1; i <= j; i, j = i+1, j-1 {
-		buf[i], buf[j] = buf[j], buf[i]
-	}
-
-	return hex.EncodeToString(buf[:])
+	return s.FieldR.String()
 }
 
 // Add adds two scalars together s.t. s = a+b
 func (s *Scalar) Add(a, b Scalar) *Scalar {
-	s.Field.Add(a.Field, b.Field, rMod)
+	s.FieldR.Add(a.FieldR, b.FieldR)
 	return s
 }
 
+// Double sets s = 2s
 func (s *Scalar) Double() *Scalar {
-	s.Field.Double(rMod)
+	s.FieldR.Double()
 	return s
 }
 
+// BigInt returns the canonical value of s
 func (s *Scalar) BigInt() *big.Int {
 	var buf [32]byte
 	s.BytesInto(&buf)
@@ -94,40 +77,93 @@ func (s *Scalar) BigInt() *big.Int {
 
 // Sub subtracts two scalars s.t. s = a-b
 func (s *Scalar) Sub(a, b Scalar) *Scalar {
-	s.Field.Sub(a.Field, b.Field, rMod)
+	s.FieldR.Sub(a.FieldR, b.FieldR)
 	return s
 }
 
 // Mul Multiplies two scalars s.t. s = a * b
 func (s *Scalar) Mul(a, b Scalar) *Scalar {
-	s.Field.Mul(a.Field, b.Field, INV, rMod)
+	s.FieldR.Mul(a.FieldR, b.FieldR)
 	return s
 }
 
-// // Inverse returns the inverse s.t. s = 1/a
-// func (s *Scalar) Inverse(a *Scalar) *Scalar {
-// 	return nil
-// }
+// Invert sets s = 1/a, computed as a^(r-2). The inverse of zero is zero.
+// Runs in constant time
+func (s *Scalar) Invert(a Scalar) *Scalar {
+	s.FieldR.Invert(a.FieldR)
+	return s
+}
+
+// Pow sets s = a^e, where e is given as little endian 64 bit limbs.
+// Runs in constant time with respect to both a and e
+func (s *Scalar) Pow(a Scalar, e [4]uint64) *Scalar {
+	s.FieldR.Pow(a.FieldR, e)
+	return s
+}
+
+// Sqrt sets s to a square root of a and returns true if a is a square.
+// Otherwise s is set to an unspecified value and it returns false.
+// Runs in constant time
+func (s *Scalar) Sqrt(a Scalar) (*Scalar, bool) {
+	isSquare := s.FieldR.Sqrt(a.FieldR)
+	return s, isSquare == 1
+}
+
+// BatchInvert replaces every scalar of scalars with its inverse, with a
+// single inversion (Montgomery's trick). Zeros are left as zeros.
+// Runs in constant time with respect to the values of scalars
+func BatchInvert(scalars []Scalar) {
+	var one Scalar
+	one.SetOne()
+
+	// prods[i] is the product of the non-zero scalars before i
+	prods := make([]Scalar, len(scalars))
+	var acc, x Scalar
+	acc.SetOne()
+	for i := range scalars {
+		prods[i] = acc
+		x.CondSel(one.Field, scalars[i].Field, field.ConstantTimeEq(scalars[i].Field, field.Field{}))
+		acc.Mul(acc, x)
+	}
+
+	// acc is the inverse of the product, peeled back one scalar at a time
+	acc.Invert(acc)
+	var inv Scalar
+	for i := len(scalars) - 1; i >= 0; i-- {
+		isZero := field.ConstantTimeEq(scalars[i].Field, field.Field{})
+		x.CondSel(one.Field, scalars[i].Field, isZero)
+
+		inv.Mul(acc, prods[i])
+		acc.Mul(acc, x)
+		scalars[i].CondSel(scalars[i].Field, inv.Field, isZero)
+	}
+}
 
 // Neg returns the Negation of a scalar s.t. s = -a
 func (s *Scalar) Neg(a Scalar) *Scalar {
-	s.Field.Neg(a.Field, rMod)
+	s.FieldR.Neg(a.FieldR)
 	return s
 }
 
 // SetZero sets s = 0
 func (s *Scalar) SetZero() *Scalar {
-	s.Field.SetZero()
+	s.FieldR.SetZero()
 	return s
 }
 
 // SetOne sets s = 1
 func (s *Scalar) SetOne() *Scalar {
-	s.Field.Set(montR)
+	s.FieldR.SetOne()
 	return s
 }
 
-// Set sets s to a Field value
+// SetUint64 sets s = v
+func (s *Scalar) SetUint64(v uint64) *Scalar {
+	s.FieldR.FromU64(v)
+	return s
+}
+
+// SetField sets s to a Field value, in Montgomery form
 func (s *Scalar) SetField(a field.Field) *Scalar {
 	s.Field.Set(a)
 	return s
@@ -135,49 +171,106 @@ func (s *Scalar) SetField(a field.Field) *Scalar {
 
 // Set sets s to scalar `a`
 func (s *Scalar) Set(a Scalar) *Scalar {
-	s.Field.Set(a.Field)
+	s.FieldR.Set(a.FieldR)
 	return s
 }
 
+// Equal reports whether s and a are equal. Runs in constant time
+func (s *Scalar) Equal(a Scalar) bool {
+	return fr.Equal(s.FieldR, a.FieldR) == 1
+}
+
+// Cmp compares the values of s and a and returns -1 if s < a, 0 if
+// s = a and 1 if s > a. It is variable time
+func (s *Scalar) Cmp(a Scalar) int {
+	return int(fr.Cmp(s.FieldR, a.FieldR))
+}
+
+// ConditionalSet sets s = a if b = 1 and leaves s unchanged if b = 0.
+// Runs in constant time
+func (s *Scalar) ConditionalSet(a Scalar, b uint64) *Scalar {
+	s.FieldR.ConditionalSet(a.FieldR, b)
+	return s
+}
+
+// LegendreSymbolVarTime returns 1 if s is a non-zero square, -1 if it
+// is not a square and 0 if s = 0. It is variable time
+func (s *Scalar) LegendreSymbolVarTime() int {
+	return s.FieldR.LegendreSymbolVarTime()
+}
+
 // HashToScalar hashes the slice d into a scalar returning s mod R
+//
+// Deprecated: use the HashToScalar function, which takes a domain
+// separation tag
 func (s *Scalar) HashToScalar(d []byte) *Scalar {
-	byt := sha512.Sum512(d)
-	s.Field.FromBytes(byt, INV, rMod, montR2, montR3)
-	return s
+	return s.FromBytes(sha512.Sum512(d))
 }
 
 // Square sets s = a * a
 func (s *Scalar) Square(a Scalar) *Scalar {
-	s.Field.Square(a.Field, INV, rMod)
+	s.FieldR.Square(a.FieldR)
 	return s
 }
 
-// SetReduce sets s = a mod r
+// Reduce sets s = a mod r. Scalars are always reduced, so this is only
+// needed for values set with SetField
 func (s *Scalar) Reduce(a Scalar) *Scalar {
-	if field.Cmp(a.Field, rMod) >= 0 {
-		s.Field.Sub(a.Field, rMod, rMod)
-	}
+	s.FieldR.Reduce(a.FieldR)
 	return s
 }
 
 // MulAdd multiplies and adds three numbers s.t. s= a*b +c
 func (s *Scalar) MulAdd(a, b, c Scalar) *Scalar {
-	s.Mul(a, b).Add(*s, c)
+	s.FieldR.MulAdd(a.FieldR, b.FieldR, c.FieldR)
 	return s
 }
 
 // MulSub multiplies and subtracts three numbers s.t. s= a*b -c
 func (s *Scalar) MulSub(a, b, c Scalar) *Scalar {
-	s.Mul(a, b).Sub(*s, c)
+	s.FieldR.MulSub(a.FieldR, b.FieldR, c.FieldR)
 	return s
 }
 
-// XXX: Not working, should unmarshall and marshall and it gives the same Scalar onject
+// Bytes returns the canonical 32 byte little endian encoding of s
+func (s *Scalar) Bytes() []byte {
+	return s.FieldR.IntoBytes()
+}
+
+// SetCanonicalBytes sets s to the scalar encoded in buf, as produced by
+// Bytes. Returns an error, leaving s unchanged, if the encoded value is
+// not less than r. Runs in constant time
+func (s *Scalar) SetCanonicalBytes(buf [32]byte) (*Scalar, error) {
+	if _, err := s.FieldR.SetBytes(&buf); err != nil {
+		return s, errors.New("jubjub: scalar encoding is not canonical")
+	}
+	return s, nil
+}
+
+// SetCanonicalBytesSlice is SetCanonicalBytes for an encoding held in
+// a slice. Returns an error, leaving s unchanged, if b is not 32 bytes long
+func (s *Scalar) SetCanonicalBytesSlice(b []byte) (*Scalar, error) {
+	if len(b) != 32 {
+		return s, errors.New("jubjub: invalid scalar length")
+	}
+
+	var buf [32]byte
+	copy(buf[:], b)
+	return s.SetCanonicalBytes(buf)
+}
+
+// SetUniformBytes sets s to the 512 bit little endian value in buf reduced
+// mod r. If buf is uniformly random, the bias of s is negligible
+func (s *Scalar) SetUniformBytes(buf [64]byte) *Scalar {
+	return s.FromBytes(buf)
+}
+
+// SetBytes sets s to the 256 bit little endian value in buf reduced mod r
+//
+// Deprecated: use SetCanonicalBytes to decode scalars, or
+// SetUniformBytes to derive them from random bytes
 func (s *Scalar) SetBytes(buf *[32]byte) *Scalar {
-	s.Field[0] = futil.Load4(buf[0:])
-	s.Field[1] = futil.Load4(buf[8:])
-	s.Field[2] = futil.Load4(buf[16:])
-	s.Field[3] = futil.Load4(buf[24:]) & 0x1fffffff
-	s.Field.Sub(s.Field, rMod, rMod)
-	return s
+	var wide [64]byte
+	copy(wide[:], buf[:])
+	return s.FromBytes(wide)
 }
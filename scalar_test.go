@@ -0,0 +1,332 @@
+package jubjub
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var scalarModulus, _ = new(big.Int).SetString("0e7db4ea6533afa906673b0101343b00a6682093ccc81082d0970e5ed6f72cb7", 16)
+
+// scalarFromBig returns x mod r as a Scalar
+func scalarFromBig(x *big.Int) Scalar {
+	x = new(big.Int).Mod(x, scalarModulus)
+
+	var buf [64]byte
+	b := x.Bytes()
+	for i := range b {
+		buf[i] = b[len(b)-1-i]
+	}
+
+	var s Scalar
+	s.FromBytes(buf)
+	return s
+}
+
+func TestScalarInvert(t *testing.T) {
+	var zero, one, s, inv Scalar
+	one.SetOne()
+
+	s.Invert(zero)
+	assert.True(t, s.IsZero())
+
+	s.Invert(one)
+	assert.Equal(t, one, s)
+
+	for i := 0; i < 32; i++ {
+		a := randomScalar()
+		inv.Invert(a)
+
+		s.Mul(a, inv)
+		assert.Equal(t, one, s)
+
+		expected := new(big.Int).ModInverse(a.BigInt(), scalarModulus)
+		assert.Equal(t, 0, expected.Cmp(inv.BigInt()))
+	}
+}
+
+func TestScalarPow(t *testing.T) {
+	var one, s Scalar
+	one.SetOne()
+
+	a := randomScalar()
+	s.Pow(a, [4]uint64{})
+	assert.Equal(t, one, s)
+	s.Pow(a, [4]uint64{1})
+	assert.Equal(t, a, s)
+
+	for i := 0; i < 16; i++ {
+		a, b := randomScalar(), randomScalar()
+		var e [4]uint64
+		for j := range e {
+			e[j] = b.Field[j]
+		}
+		s.Pow(a, e)
+
+		be := new(big.Int)
+		for j := len(e) - 1; j >= 0; j-- {
+			be.Lsh(be, 64)
+			be.Or(be, new(big.Int).SetUint64(e[j]))
+		}
+		expected := new(big.Int).Exp(a.BigInt(), be, scalarModulus)
+		assert.Equal(t, 0, expected.Cmp(s.BigInt()))
+	}
+}
+
+func TestScalarSqrt(t *testing.T) {
+	var zero, root, sq Scalar
+
+	_, ok := root.Sqrt(zero)
+	assert.True(t, ok)
+	assert.True(t, root.IsZero())
+
+	var squares, nonSquares int
+	for i := 0; i < 64; i++ {
+		a := randomScalar()
+		_, ok := root.Sqrt(a)
+
+		isSquare := big.Jacobi(a.BigInt(), scalarModulus) == 1
+		assert.Equal(t, isSquare, ok)
+		if ok {
+			squares++
+			sq.Square(root)
+			assert.Equal(t, a, sq)
+		} else {
+			nonSquares++
+		}
+
+		// a^2 is always a square, with roots a and -a
+		sq.Square(a)
+		_, ok = root.Sqrt(sq)
+		assert.True(t, ok)
+		var neg Scalar
+		neg.Neg(a)
+		assert.True(t, root == a || root == neg)
+	}
+	assert.True(t, squares > 0)
+	assert.True(t, nonSquares > 0)
+
+	// 3 is the smallest non-square modulo r
+	_, ok = root.Sqrt(scalarFromBig(big.NewInt(3)))
+	assert.False(t, ok)
+}
+
+func TestScalarLegendreSymbol(t *testing.T) {
+	var zero Scalar
+	assert.Equal(t, 0, zero.LegendreSymbolVarTime())
+
+	for i := 0; i < 32; i++ {
+		a := randomScalar()
+		assert.Equal(t, big.Jacobi(a.BigInt(), scalarModulus), a.LegendreSymbolVarTime())
+	}
+
+	three := scalarFromBig(big.NewInt(3))
+	assert.Equal(t, -1, three.LegendreSymbolVarTime())
+}
+
+func TestScalarCmp(t *testing.T) {
+	// Montgomery form does not preserve order, the canonical values are compared
+	for i := 0; i < 32; i++ {
+		a, b := randomScalar(), randomScalar()
+		assert.Equal(t, a.BigInt().Cmp(b.BigInt()), a.Cmp(b))
+		assert.Equal(t, 0, a.Cmp(a))
+	}
+
+	one := scalarFromBig(big.NewInt(1))
+	minusOne := scalarFromBig(new(big.Int).Sub(scalarModulus, big.NewInt(1)))
+	assert.Equal(t, -1, one.Cmp(minusOne))
+	assert.Equal(t, 1, minusOne.Cmp(one))
+}
+
+func TestScalarConditionalSet(t *testing.T) {
+	a, b := randomScalar(), randomScalar()
+
+	s := a
+	s.ConditionalSet(b, 0)
+	assert.Equal(t, a, s)
+	s.ConditionalSet(b, 1)
+	assert.Equal(t, b, s)
+}
+
+func TestBatchInvert(t *testing.T) {
+	BatchInvert(nil)
+
+	scalars := make([]Scalar, 9)
+	for i := range scalars {
+		if i%4 != 0 {
+			scalars[i] = randomScalar()
+		}
+	}
+	inputs := append([]Scalar{}, scalars...)
+
+	BatchInvert(scalars)
+	for i := range scalars {
+		var expected Scalar
+		expected.Invert(inputs[i])
+		assert.Equal(t, expected, scalars[i], "index %d", i)
+	}
+}
+
+func BenchmarkScalarInvert(b *testing.B) {
+	s := randomScalar()
+	for i := 0; i < b.N; i++ {
+		s.Invert(s)
+	}
+}
+
+// scalarBytes returns the 32 byte little endian encoding of x
+func scalarBytes(x *big.Int) [32]byte {
+	var buf [32]byte
+	b := x.Bytes()
+	for i := range b {
+		buf[i] = b[len(b)-1-i]
+	}
+	return buf
+}
+
+// bigFromBytes returns the little endian value of b
+func bigFromBytes(b []byte) *big.Int {
+	be := make([]byte, len(b))
+	for i := range b {
+		be[i] = b[len(b)-1-i]
+	}
+	return new(big.Int).SetBytes(be)
+}
+
+func TestScalarBytesRoundTrip(t *testing.T) {
+	var s Scalar
+	for i := 0; i < 64; i++ {
+		a := randomScalar()
+
+		var buf [32]byte
+		copy(buf[:], a.Bytes())
+		_, err := s.SetCanonicalBytes(buf)
+		assert.Nil(t, err)
+		assert.Equal(t, a, s)
+		assert.Equal(t, a.Bytes(), s.Bytes())
+	}
+}
+
+func TestScalarSetCanonicalBytes(t *testing.T) {
+	one := big.NewInt(1)
+	tests := []struct {
+		name  string
+		value *big.Int
+		ok    bool
+	}{
+		{"zero", big.NewInt(0), true},
+		{"one", one, true},
+		{"r - 1", new(big.Int).Sub(scalarModulus, one), true},
+		{"r", scalarModulus, false},
+		{"r + 1", new(big.Int).Add(scalarModulus, one), false},
+		{"2^255", new(big.Int).Lsh(one, 255), false},
+		{"2^256 - 1", new(big.Int).Sub(new(big.Int).Lsh(one, 256), one), false},
+	}
+
+	for _, tc := range tests {
+		s := randomScalar()
+		before := s
+
+		_, err := s.SetCanonicalBytes(scalarBytes(tc.value))
+		if tc.ok {
+			assert.Nil(t, err, tc.name)
+			assert.Equal(t, 0, tc.value.Cmp(s.BigInt()), tc.name)
+		} else {
+			assert.NotNil(t, err, tc.name)
+			assert.Equal(t, before, s, tc.name)
+		}
+	}
+}
+
+func TestScalarSetCanonicalBytesSlice(t *testing.T) {
+	a := randomScalar()
+
+	var s Scalar
+	_, err := s.SetCanonicalBytesSlice(a.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, a, s)
+
+	r := scalarBytes(scalarModulus)
+	for _, b := range [][]byte{nil, a.Bytes()[:31], append(a.Bytes(), 0), r[:]} {
+		_, err := s.SetCanonicalBytesSlice(b)
+		assert.NotNil(t, err, "%x", b)
+		assert.Equal(t, a, s)
+	}
+}
+
+func TestScalarSetBytes(t *testing.T) {
+	var s Scalar
+	for _, x := range []*big.Int{big.NewInt(7), scalarModulus, new(big.Int).Lsh(big.NewInt(1), 255)} {
+		buf := scalarBytes(x)
+		s.SetBytes(&buf)
+		assert.Equal(t, 0, new(big.Int).Mod(x, scalarModulus).Cmp(s.BigInt()))
+	}
+}
+
+func FuzzScalarSetCanonicalBytes(f *testing.F) {
+	f.Add(make([]byte, 32))
+	r := scalarBytes(scalarModulus)
+	f.Add(r[:])
+	f.Add(bytes.Repeat([]byte{0xff}, 32))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) != 32 {
+			return
+		}
+		var buf [32]byte
+		copy(buf[:], data)
+
+		var s Scalar
+		_, err := s.SetCanonicalBytes(buf)
+		if bigFromBytes(data).Cmp(scalarModulus) >= 0 {
+			if err == nil {
+				t.Fatalf("accepted non-canonical encoding %x", data)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("rejected canonical encoding %x", data)
+		}
+		if !bytes.Equal(s.Bytes(), data) {
+			t.Fatalf("encoding %x round-tripped to %x", data, s.Bytes())
+		}
+	})
+}
+
+func FuzzScalarSetUniformBytes(f *testing.F) {
+	f.Add(make([]byte, 64))
+	f.Add(bytes.Repeat([]byte{0xff}, 64))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) != 64 {
+			return
+		}
+		var buf [64]byte
+		copy(buf[:], data)
+
+		var s Scalar
+		s.SetUniformBytes(buf)
+
+		expected := new(big.Int).Mod(bigFromBytes(data), scalarModulus)
+		if expected.Cmp(s.BigInt()) != 0 {
+			t.Fatalf("%x reduced to %v, expected %v", data, s.BigInt(), expected)
+		}
+	})
+}
+
+func TestScalarSetUint64(t *testing.T) {
+	var s Scalar
+	for _, v := range []uint64{0, 1, 42, 1<<64 - 1} {
+		s.SetUint64(v)
+		assert.Equal(t, 0, new(big.Int).SetUint64(v).Cmp(s.BigInt()))
+	}
+
+	var a, b Scalar
+	a.SetUint64(7)
+	b.SetUint64(7)
+	assert.True(t, a.Equal(b))
+	b.SetUint64(8)
+	assert.False(t, a.Equal(b))
+}
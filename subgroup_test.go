@@ -0,0 +1,124 @@
+package jubjub
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// torsionPoint returns a point of small order, computed as
+// r * q = (r - 1) * q + q for a point q outside the subgroup
+func torsionPoint() Point {
+	var q, t Point
+	q.HashToPoint([]byte("torsion"))
+
+	var minusOne Scalar
+	minusOne.SetOne()
+	minusOne.Neg(minusOne)
+
+	t.ScalarMult(minusOne, &q).Add(t, q)
+	return t
+}
+
+func TestSubgroupChecks(t *testing.T) {
+	var id, q, p Point
+	id.Identity()
+	q.HashToPoint([]byte("q"))
+	p.ClearCofactor(q)
+	torsion := torsionPoint()
+
+	assert.False(t, torsion.IsIdentity())
+
+	tests := []struct {
+		name                           string
+		point                          Point
+		smallOrder, torsionFree, prime bool
+	}{
+		{"identity", id, true, true, false},
+		{"torsion", torsion, true, false, false},
+		{"prime order", p, false, true, true},
+		{"full order", q, false, false, false},
+		{"prime order plus torsion", *new(Point).Add(p, torsion), false, false, false},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.smallOrder, tc.point.IsSmallOrder(), tc.name)
+		assert.Equal(t, tc.torsionFree, tc.point.IsTorsionFree(), tc.name)
+		assert.Equal(t, tc.prime, tc.point.IsPrimeOrder(), tc.name)
+	}
+}
+
+func TestClearCofactor(t *testing.T) {
+	var q, expected, res Point
+	q.HashToPoint([]byte("q"))
+	expected.Double(q).Double(expected).Double(expected)
+
+	res.ClearCofactor(q)
+	assert.True(t, res.Equal(expected))
+	assert.True(t, res.IsTorsionFree())
+
+	// Clearing the cofactor of a torsion point gives the identity
+	res.ClearCofactor(torsionPoint())
+	assert.True(t, res.IsIdentity())
+}
+
+func TestSetBytesSubgroup(t *testing.T) {
+	var q, p, res Point
+	q.HashToPoint([]byte("q"))
+	p.ClearCofactor(q)
+
+	_, err := res.SetBytesSubgroup(p.Bytes())
+	assert.Nil(t, err)
+	assert.True(t, res.Equal(p))
+
+	_, err = res.SetBytesSubgroup(q.Bytes())
+	assert.NotNil(t, err)
+
+	torsion := torsionPoint()
+	_, err = res.SetBytesSubgroup(torsion.Bytes())
+	assert.NotNil(t, err)
+
+	// res is unchanged on error
+	assert.True(t, res.Equal(p))
+}
+
+// encodeLimbs returns the encoding of the point with the affine
+// coordinates given as little endian 64 bit limbs: v with the sign of
+// u in the top bit
+func encodeLimbs(u, v [4]uint64) []byte {
+	buf := make([]byte, 32)
+	for i := range v {
+		binary.LittleEndian.PutUint64(buf[8*i:], v[i])
+	}
+	buf[31] |= byte(u[0]&1) << 7
+	return buf
+}
+
+// The generators of zkcrypto/jubjub, from SubgroupPoint::generator and
+// ExtendedPoint::generator in src/lib.rs, as the limbs of their affine
+// coordinates (u, v) in canonical form
+func TestBasePoint(t *testing.T) {
+	var b Point
+	b.SetBase()
+	assert.True(t, b.IsPrimeOrder())
+
+	expected := encodeLimbs(
+		[4]uint64{0x4df7b7ffec7beaca, 0x2e3ebb21fd6c54ed, 0xf1fbf02d0fd6cce6, 0x3fd2814c43ac65a6},
+		[4]uint64{0x0000000000000012, 0, 0, 0},
+	)
+	assert.Equal(t, hex.EncodeToString(expected), hex.EncodeToString(b.Bytes()))
+	assert.Equal(t, "1200000000000000000000000000000000000000000000000000000000000000", hex.EncodeToString(b.Bytes()))
+
+	// The generator of the full group has order 8r, it is not the
+	// base point times a cofactor
+	var g Point
+	_, err := g.SetBytes(encodeLimbs(
+		[4]uint64{0xe4b3d35df1a7adfe, 0xcaf55d1b29bf81af, 0x8b0f03ddd60a8187, 0x62edcbb8bf3787c8},
+		[4]uint64{0x000000000000000b, 0, 0, 0},
+	))
+	assert.Nil(t, err)
+	assert.False(t, g.IsSmallOrder())
+	assert.False(t, g.IsTorsionFree())
+}
@@ -0,0 +1,105 @@
+package jubjub
+
+import (
+	"crypto/rand"
+	"math"
+	"os"
+	"sort"
+	"testing"
+	"time"
+)
+
+// welchT returns Welch's t-statistic for two sets of measurements
+func welchT(a, b []float64) float64 {
+	mean := func(x []float64) float64 {
+		var s float64
+		for _, v := range x {
+			s += v
+		}
+		return s / float64(len(x))
+	}
+	variance := func(x []float64, m float64) float64 {
+		var s float64
+		for _, v := range x {
+			s += (v - m) * (v - m)
+		}
+		return s / float64(len(x)-1)
+	}
+
+	ma, mb := mean(a), mean(b)
+	va, vb := variance(a, ma), variance(b, mb)
+
+	return (ma - mb) / math.Sqrt(va/float64(len(a))+vb/float64(len(b)))
+}
+
+// crop drops the measurements above the given percentile, which are
+// dominated by noise such as interrupts and garbage collection
+func crop(x []float64, percentile float64) []float64 {
+	sorted := append([]float64(nil), x...)
+	sort.Float64s(sorted)
+	threshold := sorted[int(percentile*float64(len(sorted)-1))]
+
+	var res []float64
+	for _, v := range x {
+		if v <= threshold {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// TestScalarMultConstantTime is a dudect style test: it times scalar
+// multiplication for a fixed scalar and for random scalars, in a random
+// interleaved order, and checks that Welch's t-test cannot distinguish
+// the two classes. See https://eprint.iacr.org/2016/1123.pdf
+//
+// The result depends on the load of the machine, so the test only runs
+// when JUBJUB_TIMING_TEST is set
+func TestScalarMultConstantTime(t *testing.T) {
+	if os.Getenv("JUBJUB_TIMING_TEST") == "" {
+		t.Skip("set JUBJUB_TIMING_TEST=1 to run the timing test")
+	}
+
+	const measurements = 2000
+
+	// The fixed class uses the zero scalar, which selects the identity
+	// from every window and is the most likely to show a difference
+	var fixed Scalar
+	fixed.SetZero()
+
+	var p Point
+	p.HashToPoint([]byte("dudect"))
+
+	classes := make([]byte, measurements)
+	rand.Read(classes)
+
+	var fixedTimes, randomTimes []float64
+	var s, random Scalar
+	var res Point
+	for i := 0; i < measurements; i++ {
+		// Both classes do the same work before the measurement
+		random.Rand()
+		s.Set(random)
+		if classes[i]&1 == 0 {
+			s.Set(fixed)
+		}
+
+		start := time.Now()
+		res.ScalarMult(s, &p)
+		elapsed := float64(time.Since(start))
+
+		if classes[i]&1 == 0 {
+			fixedTimes = append(fixedTimes, elapsed)
+		} else {
+			randomTimes = append(randomTimes, elapsed)
+		}
+	}
+
+	tStat := welchT(crop(fixedTimes, 0.9), crop(randomTimes, 0.9))
+
+	// dudect treats |t| > 4.5 as evidence of a leak, a larger
+	// bound is used to keep the test stable on noisy machines
+	if math.Abs(tStat) > 10 {
+		t.Errorf("timing of ScalarMult depends on the scalar: t = %.2f", tStat)
+	}
+}